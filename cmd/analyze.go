@@ -0,0 +1,231 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/meyermarcel/icm/internal/cont"
+	"github.com/spf13/cobra"
+)
+
+const (
+	analyzeOutputTable = "table"
+	analyzeOutputJSON  = "json"
+)
+
+const analyzeOutputModesInfo = analyzeOutputTable + ` = aligned columns for humans
+ ` + analyzeOutputJSON + ` = JSON object`
+
+type analyzeOutputValue struct {
+	value string
+}
+
+func (o *analyzeOutputValue) String() string {
+	return o.value
+}
+
+func (o *analyzeOutputValue) Set(value string) error {
+	switch value {
+	case analyzeOutputTable, analyzeOutputJSON:
+		o.value = value
+		return nil
+	}
+	return fmt.Errorf("%s is not \n%s", value, analyzeOutputModesInfo)
+}
+
+func (*analyzeOutputValue) Type() string {
+	return "string"
+}
+
+func newAnalyzeCmd(writer io.Writer, decoders decoders) *cobra.Command {
+	analyzeCmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Analyze capacity and risks of container numbers",
+		Long:  "Analyze capacity and risks of container numbers.",
+		Example: `  icm analyze owner ABC
+  icm analyze owner ABC --fleet-file fleet.txt --output json`,
+		Args: cobra.NoArgs,
+	}
+
+	analyzeCmd.AddCommand(newAnalyzeOwnerCmd(writer, decoders))
+
+	return analyzeCmd
+}
+
+func newAnalyzeOwnerCmd(writer io.Writer, decoders decoders) *cobra.Command {
+
+	var equipCatValue = equipCatValue{equipCatDecoder: decoders.equipCatDecoder}
+	var fleetFiles []string
+	var rangeSize int
+	var outputValue = analyzeOutputValue{value: analyzeOutputTable}
+
+	ownerCmd := &cobra.Command{
+		Use:   "owner OWNER-CODE",
+		Short: "Analyze capacity of serial numbers of an owner code",
+		Long: `Analyze capacity of serial numbers of an owner code for every equipment
+category ID. Serial numbers with check digit 10 and serial numbers with a
+possible transposition error are counted. Serial numbers of container
+numbers in a fleet file are counted as used. Every line of a fleet file
+contains one container number. Empty lines are ignored. Check digits are
+not validated, so a container number with a wrong or missing check digit
+is counted as used as well. Free serial numbers have no check digit 10,
+no possible transposition error and are not used.
+
+The capacity is printed for all serial numbers and for ranges of serial
+numbers. The --range-size flag sets the count of serial numbers of a range.`,
+		Example: `  icm analyze owner ABC
+  icm analyze owner ABC --equipment-category U --range-size 250000
+  icm analyze owner ABC --fleet-file fleet.txt
+  icm analyze owner ABC --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ownerCode := strings.ToUpper(args[0])
+			if err := cont.IsOwnerCode(ownerCode); err != nil {
+				return err
+			}
+
+			var fleet []cont.Number
+			for _, path := range fleetFiles {
+				contNums, err := readContNums(path)
+				if err != nil {
+					return err
+				}
+				fleet = append(fleet, contNums...)
+			}
+
+			equipCatIDs := decoders.equipCatDecoder.AllCatIDs()
+			if equipCatValue.value != "" {
+				equipCatIDs = []string{equipCatValue.value}
+			}
+			sort.Strings(equipCatIDs)
+
+			analysis := ownerCapacity{OwnerCode: ownerCode}
+			for _, equipCatID := range equipCatIDs {
+				total, ranges, err := cont.AnalyzeCapacity(ownerCode, equipCatID, fleet, rangeSize)
+				if err != nil {
+					return err
+				}
+				categoryCapacity := categoryCapacity{EquipCatID: equipCatID, Total: newCapacity(total)}
+				for _, r := range ranges {
+					categoryCapacity.Ranges = append(categoryCapacity.Ranges, newCapacity(r))
+				}
+				analysis.Categories = append(analysis.Categories, categoryCapacity)
+			}
+
+			if outputValue.value == analyzeOutputJSON {
+				encoder := json.NewEncoder(writer)
+				encoder.SetEscapeHTML(false)
+				return encoder.Encode(analysis)
+			}
+			_, err := io.WriteString(writer, fmtCapacityTable(analysis))
+			return err
+		},
+	}
+
+	ownerCmd.Flags().Var(&equipCatValue, "equipment-category", "analyze only equipment category ID")
+	ownerCmd.Flags().StringArrayVar(&fleetFiles, "fleet-file", nil,
+		"file with used container numbers, can be repeated")
+	ownerCmd.Flags().IntVar(&rangeSize, "range-size", 100000, "count of serial numbers of a range")
+	ownerCmd.Flags().Var(&outputValue, "output", fmt.Sprintf("sets output to\n%s\n", analyzeOutputModesInfo))
+
+	return ownerCmd
+}
+
+type capacity struct {
+	From                string `json:"from"`
+	To                  string `json:"to"`
+	Serials             int    `json:"serials"`
+	CheckDigit10        int    `json:"check-digit-10"`
+	TranspositionErrors int    `json:"transposition-errors"`
+	Used                int    `json:"used"`
+	Free                int    `json:"free"`
+}
+
+func newCapacity(c cont.Capacity) capacity {
+	return capacity{
+		From:                fmt.Sprintf("%06d", c.Range.From()),
+		To:                  fmt.Sprintf("%06d", c.Range.To()),
+		Serials:             c.Range.Len(),
+		CheckDigit10:        c.CheckDigit10,
+		TranspositionErrors: c.TranspositionErr,
+		Used:                c.Used,
+		Free:                c.Free,
+	}
+}
+
+type categoryCapacity struct {
+	EquipCatID string     `json:"equipment-category-id"`
+	Total      capacity   `json:"total"`
+	Ranges     []capacity `json:"ranges"`
+}
+
+type ownerCapacity struct {
+	OwnerCode  string             `json:"owner-code"`
+	Categories []categoryCapacity `json:"categories"`
+}
+
+// fmtCapacityTable formats the capacities as a table with a row for all
+// serial numbers followed by a row for every range of every category.
+func fmtCapacityTable(analysis ownerCapacity) string {
+	rows := [][]string{{"category", "range", "serials", "check digit 10", "transposition errors", "used", "free"}}
+	for _, category := range analysis.Categories {
+		for _, c := range append([]capacity{category.Total}, category.Ranges...) {
+			rows = append(rows, []string{
+				category.EquipCatID,
+				c.From + "-" + c.To,
+				strconv.Itoa(c.Serials),
+				strconv.Itoa(c.CheckDigit10),
+				strconv.Itoa(c.TranspositionErrors),
+				strconv.Itoa(c.Used),
+				strconv.Itoa(c.Free),
+			})
+		}
+	}
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for idx, cell := range row {
+			if len(cell) > widths[idx] {
+				widths[idx] = len(cell)
+			}
+		}
+	}
+
+	b := strings.Builder{}
+	b.WriteString(fmt.Sprintf("%s %s\n\n", bold("Capacity of owner code"), underline(analysis.OwnerCode)))
+	for rowIdx, row := range rows {
+		for idx, cell := range row {
+			if idx > 0 {
+				b.WriteString("  ")
+			}
+			// Text columns are left aligned and count columns are right aligned.
+			if idx < 2 {
+				cell = fmt.Sprintf("%-*s", widths[idx], cell)
+			} else {
+				cell = fmt.Sprintf("%*s", widths[idx], cell)
+			}
+			if rowIdx == 0 {
+				cell = bold(cell)
+			}
+			b.WriteString(cell)
+		}
+		b.WriteString(fmt.Sprintln())
+	}
+	return b.String()
+}
@@ -0,0 +1,122 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func Test_analyzeOwnerCmd(t *testing.T) {
+	fleet, err := ioutil.TempFile("", "fleet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(fleet.Name())
+	// The check digit of ABCU000000 is 1 and a wrong check digit is ignored.
+	if _, err := fleet.WriteString("ABC U 000000 7\n\nDEFU0000022\n"); err != nil {
+		t.Fatal(err)
+	}
+	fleet.Close()
+
+	tests := []struct {
+		name       string
+		args       []string
+		output     string
+		wantErr    bool
+		wantWriter string
+	}{
+		{
+			"Analyze owner as table",
+			[]string{"abc"},
+			"table",
+			false,
+			`Capacity of owner code ABC
+
+category  range          serials  check digit 10  transposition errors  used    free
+U         000000-999999  1000000           90909                 83124     1  865790
+U         000000-499999   500000           45454                 41537     1  433164
+U         500000-999999   500000           45455                 41587     0  432626
+`,
+		},
+		{
+			"Analyze owner as JSON",
+			[]string{"ABC"},
+			"json",
+			false,
+			`{"owner-code":"ABC","categories":[{"equipment-category-id":"U",` +
+				`"total":{"from":"000000","to":"999999","serials":1000000,"check-digit-10":90909,"transposition-errors":83124,"used":1,"free":865790},` +
+				`"ranges":[{"from":"000000","to":"499999","serials":500000,"check-digit-10":45454,"transposition-errors":41537,"used":1,"free":433164},` +
+				`{"from":"500000","to":"999999","serials":500000,"check-digit-10":45455,"transposition-errors":41587,"used":0,"free":432626}]}]}
+`,
+		},
+		{
+			"Analyze invalid owner code returns error",
+			[]string{"AB"},
+			"table",
+			true,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+			cmd := newAnalyzeOwnerCmd(writer, newDummyDecoders())
+			_ = cmd.Flags().Set("fleet-file", fleet.Name())
+			_ = cmd.Flags().Set("range-size", "500000")
+			_ = cmd.Flags().Set("output", tt.output)
+			if got := cmd.RunE(cmd, tt.args); (got == nil) == tt.wantErr {
+				t.Errorf("got = %v, wantErr is %v", got, tt.wantErr)
+			}
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+		})
+	}
+}
+
+type unorderedEquipCatDecoder struct {
+	dummyEquipCatDecoder
+}
+
+func (unorderedEquipCatDecoder) AllCatIDs() []string {
+	return []string{"Z", "U", "J"}
+}
+
+func Test_analyzeOwnerCmd_categoryOrder(t *testing.T) {
+	writer := &bytes.Buffer{}
+	d := newDummyDecoders()
+	d.equipCatDecoder = unorderedEquipCatDecoder{}
+	cmd := newAnalyzeOwnerCmd(writer, d)
+	_ = cmd.Flags().Set("range-size", "1000000")
+	_ = cmd.Flags().Set("output", "json")
+	if err := cmd.RunE(cmd, []string{"ABC"}); err != nil {
+		t.Fatal(err)
+	}
+	var analysis ownerCapacity
+	if err := json.Unmarshal(writer.Bytes(), &analysis); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, category := range analysis.Categories {
+		got = append(got, category.EquipCatID)
+	}
+	if want := []string{"J", "U", "Z"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got categories %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/meyermarcel/icm/configs"
+	"github.com/meyermarcel/icm/internal/cont"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newCompleteCmd(stdin io.Reader, writer, writerErr io.Writer, viper *viper.Viper, decoders decoders) *cobra.Command {
+	completeCmd := &cobra.Command{
+		Use:   "complete",
+		Short: "Complete partially known container numbers",
+		Long: `Complete partially known container numbers.
+
+Unknown characters are marked with '` + string(cont.Placeholder) + `'. Every container
+number with a correct check digit is printed. Unknown owner codes and
+equipment category IDs are completed with the owners and categories in
+
+  ` + filepath.Join("$HOME", appDir, "data") + `
+
+A missing check digit is appended to owner code, equipment category ID and
+serial number. A warning is printed for serial numbers that generate
+check digit 10.
+
+If no argument is passed every line of stdin is completed. Lines that
+cannot be completed are printed to stderr and the remaining lines are
+still completed. Passed owner codes that are not registered are kept
+and a warning is printed.
+
+` + sepHelp,
+		Example: `  icm complete 'ABCU12?456?'
+  icm complete '??C U 123456 0'
+  icm complete --sep-owner-equip '' 'ABC U 1234?6 0'
+  icm complete ABCU123456
+  cat damaged.txt | icm complete`,
+		Args: cobra.MaximumNArgs(4),
+		// https://github.com/spf13/viper/issues/233
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := viper.BindPFlag(configs.SepOE, cmd.Flags().Lookup(configs.SepOE)); err != nil {
+				return err
+			}
+			if err := viper.BindPFlag(configs.SepES, cmd.Flags().Lookup(configs.SepES)); err != nil {
+				return err
+			}
+			return viper.BindPFlag(configs.SepSC, cmd.Flags().Lookup(configs.SepSC))
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var reader io.Reader
+			if len(args) != 0 {
+				reader = strings.NewReader(strings.Join(args, " "))
+			} else {
+				reader = stdin
+			}
+
+			isRegistered := func(code string) bool {
+				found, _ := decoders.ownerDecodeUpdater.Decode(code)
+				return found
+			}
+			notCompleted := 0
+			scanner := bufio.NewScanner(reader)
+			for scanner.Scan() {
+				pattern := toPattern(scanner.Text())
+				if pattern == "" {
+					continue
+				}
+				if len(pattern) == 10 {
+					pattern += string(cont.Placeholder)
+				}
+				isOwnerCode := isRegistered
+				// A passed owner code is kept even if it is not registered.
+				if len(pattern) >= 3 {
+					if ownerCode := strings.ToUpper(pattern[:3]); cont.IsOwnerCode(ownerCode) == nil && !isRegistered(ownerCode) {
+						isOwnerCode = func(code string) bool { return code == ownerCode }
+						writeErr(writerErr, fmt.Errorf("%s is not a registered owner code", ownerCode))
+					}
+				}
+				contNums, err := cont.Complete(pattern,
+					isOwnerCode,
+					func(ID string) bool {
+						found, _ := decoders.equipCatDecoder.Decode(ID)
+						return found
+					})
+				if err != nil {
+					writeErr(writerErr, err)
+					notCompleted++
+					continue
+				}
+				for _, contNum := range contNums {
+					contNum.SetSeparators(
+						viper.GetString(configs.SepOE),
+						viper.GetString(configs.SepES),
+						viper.GetString(configs.SepSC),
+					)
+					if _, err := io.WriteString(writer, fmt.Sprintf("%s\n", contNum)); err != nil {
+						return err
+					}
+					checkDigit := cont.CalcCheckDigit(contNum.OwnerCode(), contNum.EquipCatID(), contNum.SerialNumber())
+					if infos := appendCheckDigit10Info(checkDigit, nil); infos != nil {
+						texts := make([]string, 0, len(infos))
+						for _, info := range infos {
+							texts = append(texts, info.Text)
+						}
+						writeErr(writerErr, fmt.Errorf("%s: %s", contNum, strings.Join(texts, " ")))
+					}
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				return err
+			}
+			if notCompleted > 0 {
+				return fmt.Errorf("%d lines are not completed", notCompleted)
+			}
+			return nil
+		},
+	}
+
+	completeCmd.Flags().String(configs.SepOE, configs.SepOEDefVal,
+		"ABC(*)U1234560  (*) separates owner code and equipment category id")
+	completeCmd.Flags().String(configs.SepES, configs.SepESDefVal,
+		"ABCU(*)1234560  (*) separates equipment category id and serial number")
+	completeCmd.Flags().String(configs.SepSC, configs.SepSCDefVal,
+		"ABCU123456(*)0  (*) separates serial number and check digit")
+
+	return completeCmd
+}
+
+// toPattern removes all characters from a line except letters, digits and placeholders.
+func toPattern(line string) string {
+	b := strings.Builder{}
+	for _, r := range line {
+		if r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == cont.Placeholder {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
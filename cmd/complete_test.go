@@ -0,0 +1,189 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/meyermarcel/icm/configs"
+	"github.com/spf13/viper"
+)
+
+func Test_completeCmd(t *testing.T) {
+	type cfgOverride struct {
+		name  string
+		value string
+	}
+	tests := []struct {
+		name         string
+		args         []string
+		stdin        string
+		cfgOverrides []cfgOverride
+		wantErr      bool
+		wantWriter   string
+		wantErrOut   string
+	}{
+		{
+			"Complete serial number",
+			[]string{"ABC U 1234?6 0"},
+			"",
+			nil,
+			false,
+			`ABC U 123416 0
+ABC U 123456 0
+`,
+			`icm: ABC U 123416 0: It is not recommended to use a serial number that generates check digit 10 (0).
+`,
+		},
+		{
+			"Complete owner code",
+			[]string{"??C U 123456 0"},
+			"",
+			nil,
+			false,
+			`ABC U 123456 0
+`,
+			"",
+		},
+		{
+			"Complete lines of stdin with custom separators",
+			nil,
+			"abc u 12345? 0\n\nabcu12?456?\n",
+			[]cfgOverride{
+				{configs.SepOE, ""},
+				{configs.SepES, ""},
+				{configs.SepSC, "-"},
+			},
+			false,
+			`ABCU123454-0
+ABCU123456-0
+ABCU120456-6
+ABCU121456-4
+ABCU122456-2
+ABCU123456-0
+ABCU124456-9
+ABCU125456-7
+ABCU126456-5
+ABCU127456-3
+ABCU128456-1
+ABCU129456-0
+`,
+			`icm: ABCU123454-0: It is not recommended to use a serial number that generates check digit 10 (0).
+icm: ABCU129456-0: It is not recommended to use a serial number that generates check digit 10 (0).
+`,
+		},
+		{
+			"Complete check digit",
+			[]string{"ABC U 123457"},
+			"",
+			nil,
+			false,
+			`ABC U 123457 6
+`,
+			"",
+		},
+		{
+			"Complete check digit 10",
+			[]string{"ABCU123416"},
+			"",
+			nil,
+			false,
+			`ABC U 123416 0
+`,
+			`icm: ABC U 123416 0: It is not recommended to use a serial number that generates check digit 10 (0).
+`,
+		},
+		{
+			"Complete returns error for invalid pattern",
+			[]string{"A1C U 123456 0"},
+			"",
+			nil,
+			true,
+			"",
+			`icm: 1 at position 2 is not a letter
+`,
+		},
+		{
+			"Complete check digit of unregistered owner code",
+			[]string{"XYZU123456"},
+			"",
+			nil,
+			false,
+			`XYZ U 123456 0
+`,
+			`icm: XYZ is not a registered owner code
+`,
+		},
+		{
+			"Complete remaining lines of stdin after invalid line",
+			nil,
+			"ABCU12345\nABCU123457\n",
+			nil,
+			true,
+			`ABC U 123457 6
+`,
+			`icm: ABCU12345 is not 11 characters long
+`,
+		},
+		{
+			"Complete remaining lines of stdin after short line",
+			nil,
+			"ABC U 123457\nAB\nABC U 123458\n",
+			nil,
+			true,
+			`ABC U 123457 6
+ABC U 123458 1
+`,
+			`icm: AB is not 11 characters long
+`,
+		},
+		{
+			"Complete short argument",
+			[]string{"AB"},
+			"",
+			nil,
+			true,
+			"",
+			`icm: AB is not 11 characters long
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+			writerErr := &bytes.Buffer{}
+			d := decoders{
+				ownerDecodeUpdater: &dummyOwnerDecodeUpdater{},
+				equipCatDecoder:    &dummyEquipCatDecoder{},
+			}
+			viperCfg := viper.New()
+			cmd := newCompleteCmd(strings.NewReader(tt.stdin), writer, writerErr, viperCfg, d)
+			_ = cmd.PreRunE(cmd, nil)
+			for _, override := range tt.cfgOverrides {
+				viperCfg.Set(override.name, override.value)
+			}
+			if got := cmd.RunE(cmd, tt.args); (got == nil) == tt.wantErr {
+				t.Errorf("got = %v, wantErr is %v", got, tt.wantErr)
+			}
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+			if gotWriterErr := writerErr.String(); gotWriterErr != tt.wantErrOut {
+				t.Errorf("gotWriterErr = %v, want %v", gotWriterErr, tt.wantErrOut)
+			}
+		})
+	}
+}
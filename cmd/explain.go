@@ -0,0 +1,192 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/meyermarcel/icm/internal/cont"
+	"github.com/meyermarcel/icm/internal/input"
+)
+
+const (
+	explain      = "explain"
+	explainFancy = "fancy"
+	explainJSON  = "json"
+)
+
+const explainModesInfo string = explainFancy + ` = human readable calculation after fancy output
+ ` + explainJSON + ` = JSON calculation instead of validation output`
+
+type explainValue struct {
+	value string
+}
+
+func (e *explainValue) String() string {
+	return e.value
+}
+
+func (e *explainValue) Set(value string) error {
+	if value != explainFancy && value != explainJSON {
+		return fmt.Errorf("%s is not \n%s", value, explainModesInfo)
+	}
+	e.value = value
+	return nil
+}
+
+func (*explainValue) Type() string {
+	return "mode"
+}
+
+// explainCheckDigit explains the check digit calculation if inputs
+// start with an owner code, equipment category ID and serial number.
+// A check digit is not needed, so the calculation of a missing check digit
+// is explained as well.
+func explainCheckDigit(inputs []input.Input) (cont.CheckDigitExplanation, string, bool) {
+	if len(inputs) < 3 {
+		return cont.CheckDigitExplanation{}, "", false
+	}
+	ownerCode, equipCatID, serialNum := inputs[0].Value(), inputs[1].Value(), inputs[2].Value()
+	if len(ownerCode+equipCatID+serialNum) != 10 {
+		return cont.CheckDigitExplanation{}, ownerCode + equipCatID + serialNum, false
+	}
+	return cont.ExplainCheckDigit(ownerCode, equipCatID, serialNum), ownerCode + equipCatID + serialNum, true
+}
+
+// explainPrinter prints the check digit calculation after the output of another printer.
+type explainPrinter struct {
+	printer input.Printer
+	writer  io.Writer
+	indent  string
+}
+
+func (ep *explainPrinter) Print(inputs []input.Input) error {
+	if err := ep.printer.Print(inputs); err != nil {
+		return err
+	}
+	explanation, chars, ok := explainCheckDigit(inputs)
+	if !ok {
+		return nil
+	}
+	_, err := io.WriteString(ep.writer, fmtExplanation(ep.indent, chars, explanation))
+	return err
+}
+
+// Close closes the wrapped printer if it needs to be closed.
+func (ep *explainPrinter) Close() error {
+	if closer, ok := ep.printer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func fmtExplanation(indent string, chars string, explanation cont.CheckDigitExplanation) string {
+	b := strings.Builder{}
+	b.WriteString(fmt.Sprintln())
+	b.WriteString(fmt.Sprintf("%s%s %s\n\n", indent, bold("Check digit calculation of"), underline(chars)))
+
+	rows := [][]string{{"character"}, {"value"}, {"weight"}, {"product"}}
+	for _, step := range explanation.Steps {
+		rows[0] = append(rows[0], step.Char)
+		rows[1] = append(rows[1], strconv.Itoa(step.Value))
+		rows[2] = append(rows[2], strconv.Itoa(step.Weight))
+		rows[3] = append(rows[3], strconv.Itoa(step.Product))
+	}
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for idx, cell := range row {
+			if len(cell) > widths[idx] {
+				widths[idx] = len(cell)
+			}
+		}
+	}
+	for _, row := range rows {
+		b.WriteString(indent)
+		for idx, cell := range row {
+			if idx == 0 {
+				b.WriteString(fmt.Sprintf("%-*s", widths[idx], cell))
+				continue
+			}
+			b.WriteString(fmt.Sprintf(" %*s", widths[idx], cell))
+		}
+		b.WriteString(fmt.Sprintln())
+	}
+
+	checkDigit := green(explanation.CheckDigit)
+	if explanation.Remainder == 10 {
+		checkDigit = fmt.Sprintf("%s → %s", yellow(10), green(explanation.CheckDigit))
+	}
+	results := [][]string{
+		{"sum of products", strconv.Itoa(explanation.Sum)},
+		{fmt.Sprintf("%d mod 11", explanation.Sum), strconv.Itoa(explanation.Remainder)},
+		{"check digit", checkDigit},
+	}
+	b.WriteString(fmt.Sprintln())
+	for _, result := range results {
+		b.WriteString(fmt.Sprintf("%s%-*s  %s\n", indent, len(results[0][0]), result[0], result[1]))
+	}
+	b.WriteString(fmt.Sprintln())
+	return b.String()
+}
+
+type explanationStep struct {
+	Character string `json:"character"`
+	Value     int    `json:"value"`
+	Weight    int    `json:"weight"`
+	Product   int    `json:"product"`
+}
+
+type explanation struct {
+	Input      string            `json:"input"`
+	Calculable bool              `json:"calculable"`
+	Steps      []explanationStep `json:"steps,omitempty"`
+	Sum        int               `json:"sum"`
+	Remainder  int               `json:"remainder"`
+	CheckDigit int               `json:"check-digit"`
+}
+
+// explainJSONPrinter prints the check digit calculation as one JSON object per line.
+type explainJSONPrinter struct {
+	encoder *json.Encoder
+}
+
+func newExplainJSONPrinter(writer io.Writer) *explainJSONPrinter {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	return &explainJSONPrinter{encoder: encoder}
+}
+
+func (ep *explainJSONPrinter) Print(inputs []input.Input) error {
+	calculation, chars, ok := explainCheckDigit(inputs)
+	e := explanation{
+		Input:      chars,
+		Calculable: ok,
+		Sum:        calculation.Sum,
+		Remainder:  calculation.Remainder,
+		CheckDigit: calculation.CheckDigit,
+	}
+	for _, step := range calculation.Steps {
+		e.Steps = append(e.Steps, explanationStep{
+			Character: step.Char,
+			Value:     step.Value,
+			Weight:    step.Weight,
+			Product:   step.Product,
+		})
+	}
+	return ep.encoder.Encode(e)
+}
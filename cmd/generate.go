@@ -14,10 +14,18 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
+	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/meyermarcel/icm/configs"
 	"github.com/meyermarcel/icm/internal/cont"
@@ -46,6 +54,130 @@ func (*ownerValue) Type() string {
 	return "string"
 }
 
+type ownersValue struct {
+	values []string
+}
+
+func (o *ownersValue) String() string {
+	return strings.Join(o.values, ",")
+}
+
+func (o *ownersValue) Set(value string) error {
+	for _, code := range strings.Split(value, ",") {
+		if err := cont.IsOwnerCode(code); err != nil {
+			return err
+		}
+		o.values = append(o.values, code)
+	}
+	return nil
+}
+
+func (*ownersValue) Type() string {
+	return "strings"
+}
+
+type codeWeightsValue struct {
+	weights map[string]int
+	isCode  func(code string) error
+}
+
+func (c *codeWeightsValue) String() string {
+	var pairs []string
+	for code, weight := range c.weights {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", code, weight))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (c *codeWeightsValue) Set(value string) error {
+	for _, pair := range strings.Split(value, ",") {
+		code, weight, err := parseCodeWeight(pair, "=", c.isCode)
+		if err != nil {
+			return err
+		}
+		if c.weights == nil {
+			c.weights = map[string]int{}
+		}
+		c.weights[code] = weight
+	}
+	return nil
+}
+
+func (*codeWeightsValue) Type() string {
+	return "code[=weight]"
+}
+
+// parseCodeWeight parses a code and an optional weight separated by sep.
+// A code without weight has weight 1.
+func parseCodeWeight(s, sep string, isCode func(code string) error) (string, int, error) {
+	parts := strings.SplitN(s, sep, 2)
+	code := strings.TrimSpace(parts[0])
+	if err := isCode(code); err != nil {
+		return "", 0, err
+	}
+	if len(parts) == 1 {
+		return code, 1, nil
+	}
+	weight, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return "", 0, err
+	}
+	if weight < 1 {
+		return "", 0, fmt.Errorf("weight %d of %s is lower than 1", weight, code)
+	}
+	return code, weight, nil
+}
+
+func isTypeGroupCode(code string) error {
+	if len(code) != 1 || code[0] < 'A' || code[0] > 'Z' {
+		return fmt.Errorf("%s is not 1 upper case letter", code)
+	}
+	return nil
+}
+
+type equipCatValue struct {
+	value           string
+	equipCatDecoder data.EquipCatDecoder
+}
+
+func (e *equipCatValue) String() string {
+	return e.value
+}
+
+func (e *equipCatValue) Set(value string) error {
+	if found, _ := e.equipCatDecoder.Decode(value); !found {
+		return fmt.Errorf("%s is not %s", value, equipCatIDsAsList(e.equipCatDecoder))
+	}
+	e.value = value
+	return nil
+}
+
+func (*equipCatValue) Type() string {
+	return "string"
+}
+
+type serialMaskValue struct {
+	mask cont.SerialNumMask
+}
+
+func (s *serialMaskValue) String() string {
+	return s.mask.String()
+}
+
+func (s *serialMaskValue) Set(value string) error {
+	mask, err := cont.ParseSerialNumMask(value)
+	if err != nil {
+		return err
+	}
+	s.mask = mask
+	return nil
+}
+
+func (*serialMaskValue) Type() string {
+	return "mask"
+}
+
 type serialNumValue struct {
 	value int
 }
@@ -70,14 +202,40 @@ func (*serialNumValue) Type() string {
 	return "int"
 }
 
-func newGenerateCmd(writer, writerErr io.Writer, viper *viper.Viper, ownerDecoder data.OwnerDecoder) *cobra.Command {
+// generateBatchSize is the count of container numbers generated at once.
+const generateBatchSize = 4096
+
+func newGenerateCmd(writer, writerErr io.Writer, viper *viper.Viper, decoders decoders,
+	ledger data.Ledger) *cobra.Command {
 
 	var count int
+	var seed int64
 	var startValue = serialNumValue{}
 	var endValue = serialNumValue{}
-	var ownerValue = ownerValue{}
+	var serialMaskValue = serialMaskValue{}
+	var ownersValue = ownersValue{}
+	var ownerFiles []string
+	var countries []string
+	var company string
+	var randomOwners int
+	var minOwnerEdits int
+	var weightsValue = codeWeightsValue{isCode: cont.IsOwnerCode}
+	var sizeType bool
+	var lengthsValue = codeWeightsValue{isCode: cont.IsLengthCode}
+	var heightWidthsValue = codeWeightsValue{isCode: cont.IsHeightWidthCode}
+	var groupsValue = codeWeightsValue{isCode: isTypeGroupCode}
+	var equipCatValue = equipCatValue{value: "U", equipCatDecoder: decoders.equipCatDecoder}
 	var excludeCheckDigit10 bool
 	var excludeTranspositionErr bool
+	var minDistance int
+	var workers int
+	var excludeFiles []string
+	var outputValue = generateOutputValue{value: generateOutputPlain}
+	var format string
+	var allocate bool
+	var note string
+	var invalid bool
+	var defectsValue = codeWeightsValue{isCode: isDefect}
 
 	generateCmd := &cobra.Command{
 		Use:   "generate",
@@ -86,10 +244,87 @@ func newGenerateCmd(writer, writerErr io.Writer, viper *viper.Viper, ownerDecode
 
   ` + filepath.Join("$HOME", appDir, "data", "owner.json") + `
 
-are used. Equipment category ID 'U' is used for every container number.
-For a custom owner code use the --owner-code flag. For a custom serial
-number use the --start and --end flags and optionally the --count flag.
+are used. Equipment category ID 'U' is used for every container number
+unless the --equipment-category flag specifies another one. A possible
+transposition error and check digit 10 are determined for the used
+equipment category ID. For a custom serial number use the --start and
+--end flags and optionally the --count flag.
 Using only the --count flag generates pseudo random serial numbers.
+The --serial-mask flag restricts serial numbers to a mask of 6 positions.
+A position is a fixed digit, a wildcard '*' for any digit or a character
+class like [0-35] or [^9]. For example 45**** reserves a block of serial
+numbers starting with 45 and [^9]***** excludes serial numbers starting
+with 9.
+The --seed flag makes the generated container numbers reproducible.
+The --workers flag checks candidates for excluded container numbers
+concurrently. The generated container numbers do not depend on the count
+of workers.
+
+The --min-distance flag guarantees that every pair of generated container
+numbers differs in at least the passed count of the 11 positions of owner
+code, equipment category ID, serial number and check digit. The equipment
+category ID is the same for all container numbers, so at most 10 positions
+differ and only 7 with a single owner code. If not enough container numbers
+are found the achievable count is printed. With the
+--start and --end flags the --count flag sets the count of container
+numbers chosen from the range.
+
+Container numbers already in use, e.g. by a fleet, are excluded with the
+--exclude-file flag. Every line of a file contains one container number.
+Empty lines are ignored. Check digits are not validated, so a container
+number with a wrong or missing check digit is excluded as well. With the
+--start flag the next free sequential serial numbers are generated.
+
+Owners are selected with the --owner and --owner-file flags. Every line
+of an owner file contains an owner code, optionally followed by a space
+and a weight. Selected owners or all owners are filtered by country with
+the --country flag and by a regular expression for the company name with
+the --company flag. The --weight flag sets weights of owner codes for
+pseudo random serial numbers. An owner with weight 3 is used 3 times as
+often as an owner with weight 1, the default weight.
+
+The --random-owners flag generates container numbers for the passed count
+of random owner codes that are not registered, e.g. for public test
+environments. The --min-owner-edits flag sets the minimum count of
+substituted, inserted or deleted letters between a random and every
+registered owner code. It is between 1 and 3.
+
+The --size-type flag appends size and type codes of
+
+  ` + filepath.Join("$HOME", appDir, "data", "size.json") + `
+  ` + filepath.Join("$HOME", appDir, "data", "type.json") + `
+
+to every container number. Size and type codes are restricted by the
+--length, --height-width and --type-group flags with optional weights,
+e.g. --length 2,4=3 generates length code 4 three times as often as
+length code 2. These flags imply the --size-type flag.
+
+The --output flag prints CSV, a JSON array or a JSON object per line
+with owner code, company, city, country, equipment category ID, serial
+number, check digit and the risk of a possible transposition error.
+The --format flag prints every container number with a Go template.
+Available fields are .Number, .Owner, .Company, .City, .Country,
+.Category, .Serial, .CheckDigit, .TranspositionRisk, .Length,
+.HeightWidth and .Type.
+
+The --allocate flag records generated container numbers in the ledger
+
+  ` + filepath.Join("$HOME", appDir, "data", "ledger.json") + `
+
+and never generates a recorded container number again. Use the ledger
+command to list and release allocated container numbers.
+
+The --invalid flag makes every generated container number invalid with a
+defect and appends the defect separated by a tab. The --defect flag
+restricts defects with optional weights and implies the --invalid flag,
+e.g. --defect check-digit=3,lowercase. Available defects are:
+
+` + defectsInfo + `
+
+For the transposition defect no container numbers with possible
+transposition errors are generated, so every transposition is detectable.
+In JSON and CSV output the fields of the valid container number are
+printed with the invalid container number and the defect.
 
 ` + sepHelp,
 		Example: `  icm generate
@@ -97,9 +332,25 @@ Using only the --count flag generates pseudo random serial numbers.
   icm generate --count 10 --sep-owner-equip '' --sep-serial-check '-'
   icm generate --count 10 --exclude-check-digit-10
   icm generate --count 10 --exclude-transposition-errors
+  icm generate --count 10 --min-distance 3
+  icm generate --count 10 --start 100000 --end 100999 --min-distance 2
   icm generate --count 10 --start 100500
   icm generate --start 100500 --end 100600
-  icm generate --start 100500 --end 100600 --owner ABC`,
+  icm generate --start 100500 --end 100600 --owner ABC
+  icm generate --count 10 --serial-mask '45****'
+  icm generate --count 10 --start 100500 --serial-mask '[^9]****[05]'
+  icm generate --count 10 --owner ABC,DEF --weight ABC=3
+  icm generate --count 10 --owner-file owners.txt
+  icm generate --count 10 --country 'Germany' --company '(?i)lines'
+  icm generate --count 10 --size-type
+  icm generate --count 10 --length 2,4=3 --height-width 2,5 --type-group G,R
+  icm generate --count 10 --equipment-category J
+  icm generate --count 10 --seed 42
+  icm generate --count 10 --start 100500 --owner ABC --exclude-file fleet.txt
+  icm generate --count 10 --owner ABC --allocate --note 'order 4711'
+  icm generate --count 10 --output csv
+  icm generate --count 10 --output ndjson
+  icm generate --count 10 --format '{{.Owner}}-{{.Serial}}'`,
 		Args: cobra.NoArgs,
 		// https://github.com/spf13/viper/issues/233
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -109,21 +360,98 @@ Using only the --count flag generates pseudo random serial numbers.
 			if err := viper.BindPFlag(configs.SepES, cmd.Flags().Lookup(configs.SepES)); err != nil {
 				return err
 			}
-			return viper.BindPFlag(configs.SepSC, cmd.Flags().Lookup(configs.SepSC))
+			if err := viper.BindPFlag(configs.SepSC, cmd.Flags().Lookup(configs.SepSC)); err != nil {
+				return err
+			}
+			if err := viper.BindPFlag(configs.SepCS, cmd.Flags().Lookup(configs.SepCS)); err != nil {
+				return err
+			}
+			if err := viper.BindPFlag(configs.SepST, cmd.Flags().Lookup(configs.SepST)); err != nil {
+				return err
+			}
+			return viper.BindPFlag(configs.NoHeader, cmd.Flags().Lookup(configs.NoHeader))
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			builder := cont.NewUniqueGeneratorBuilder().
 				Count(count).
+				EquipCatID(equipCatValue.value).
 				ExcludeCheckDigit10(excludeCheckDigit10).
-				ExcludeTranspositionErr(excludeTranspositionErr)
+				ExcludeTranspositionErr(excludeTranspositionErr).
+				MinDistance(minDistance).
+				Workers(workers)
+
+			for _, path := range excludeFiles {
+				contNums, err := readContNums(path)
+				if err != nil {
+					return err
+				}
+				builder.Exclude(contNums)
+			}
+
+			if !cmd.Flags().Changed("seed") {
+				seed = time.Now().UnixNano()
+			}
+			builder.Seed(seed)
 
-			if cmd.Flags().Changed("owner") {
-				builder.OwnerCodes([]string{ownerValue.value})
-			} else {
-				builder.OwnerCodes(ownerDecoder.GetAllOwnerCodes())
+			var defectGenerator *defectGenerator
+			if invalid || cmd.Flags().Changed("defect") {
+				if allocate {
+					return errors.New("--invalid and --allocate cannot be used together")
+				}
+				defectGenerator = newDefectGenerator(subSeed(seed, seedStreamDefect), defectsValue.weights,
+					decoders.ownerDecodeUpdater, decoders.equipCatDecoder)
+				if len(defectsValue.weights) == 0 || defectsValue.weights[defectTransposition] > 0 {
+					// Every transposition of adjacent digits must be detectable.
+					builder.ExcludeTranspositionErr(true)
+				}
 			}
 
+			var sizeTypeGenerator *cont.SizeTypeGenerator
+			if sizeType || cmd.Flags().Changed("length") || cmd.Flags().Changed("height-width") ||
+				cmd.Flags().Changed("type-group") {
+				var err error
+				sizeTypeGenerator, err = newSizeTypeGenerator(decoders.sizeTypeDecoders,
+					subSeed(seed, seedStreamSizeType),
+					lengthsValue.weights, heightWidthsValue.weights, groupsValue.weights)
+				if err != nil {
+					return err
+				}
+			}
+
+			codes := ownersValue.values
+			weights := map[string]int{}
+			for _, path := range ownerFiles {
+				fileCodes, fileWeights, err := readOwners(path)
+				if err != nil {
+					return err
+				}
+				codes = append(codes, fileCodes...)
+				for code, weight := range fileWeights {
+					weights[code] = weight
+				}
+			}
+			for code, weight := range weightsValue.weights {
+				weights[code] = weight
+			}
+			codes, err := selectOwnerCodes(decoders.ownerDecodeUpdater, codes, countries, company)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("random-owners") {
+				for _, name := range []string{"owner", "owner-file", "country", "company"} {
+					if cmd.Flags().Changed(name) {
+						return fmt.Errorf("--random-owners and --%s cannot be used together", name)
+					}
+				}
+				rnd := rand.New(rand.NewSource(subSeed(seed, seedStreamUnregisteredOwner)))
+				codes, err = newUnregisteredOwnerCodes(rnd, decoders.ownerDecodeUpdater, randomOwners, minOwnerEdits)
+				if err != nil {
+					return err
+				}
+			}
+			builder.OwnerCodes(codes).OwnerWeights(weights)
+
 			if cmd.Flags().Changed("start") {
 				builder.Start(startValue.value)
 			}
@@ -132,21 +460,84 @@ Using only the --count flag generates pseudo random serial numbers.
 				builder.End(endValue.value)
 			}
 
-			generator, err := builder.Build()
+			if cmd.Flags().Changed("serial-mask") {
+				builder.SerialNumMask(serialMaskValue.mask)
+			}
+
+			if format != "" && cmd.Flags().Changed("output") {
+				return errors.New("--format and --output cannot be used together")
+			}
+			printer, err := newGeneratedPrinter(writer, outputValue.value, format,
+				viper.GetBool(configs.NoHeader), sizeTypeGenerator != nil, defectGenerator != nil,
+				viper.GetString(configs.SepCS), viper.GetString(configs.SepST))
 			if err != nil {
 				return err
 			}
-			for generator.Generate() {
-				contNumber := generator.ContNum()
-				contNumber.SetSeparators(
-					viper.GetString(configs.SepOE),
-					viper.GetString(configs.SepES),
-					viper.GetString(configs.SepSC),
-				)
-				_, err := io.WriteString(writer, fmt.Sprintf("%s\n", contNumber))
-				writeErr(writerErr, err)
-			}
-			return nil
+
+			sepOE, sepES, sepSC := viper.GetString(configs.SepOE), viper.GetString(configs.SepES),
+				viper.GetString(configs.SepSC)
+			detailed := printer.detailed()
+			writeContNum := func(contNum cont.Number) error {
+				contNum.SetSeparators(sepOE, sepES, sepSC)
+				// Owner information and transposition risk are only looked up if printed.
+				g := generated{Number: contNum.String()}
+				if detailed {
+					g = newGenerated(contNum, decoders.ownerDecodeUpdater)
+				}
+				if sizeTypeGenerator != nil {
+					g.Length, g.HeightWidth, g.Type = sizeTypeGenerator.Generate()
+				}
+				if defectGenerator != nil {
+					g.Invalid, g.Defect = defectGenerator.Generate(contNum, sepOE, sepES, sepSC)
+				}
+				return printer.Print(g)
+			}
+
+			if !allocate {
+				generator, err := builder.Build()
+				if err != nil {
+					return err
+				}
+				batch := make([]cont.Number, generateBatchSize)
+				for n := generator.GenerateBatch(batch); n > 0; n = generator.GenerateBatch(batch) {
+					for _, contNum := range batch[:n] {
+						if err := writeContNum(contNum); err != nil {
+							return err
+						}
+					}
+				}
+				if err := generator.Err(); err != nil {
+					return err
+				}
+				return printer.Close()
+			}
+
+			var allocated []cont.Allocation
+			err = ledger.Allocate(func(allocations []cont.Allocation) ([]cont.Allocation, error) {
+				contNums := make([]cont.Number, 0, len(allocations))
+				for _, a := range allocations {
+					contNums = append(contNums, a.ContNum)
+				}
+				generator, err := builder.Exclude(contNums).Build()
+				if err != nil {
+					return nil, err
+				}
+				now := time.Now()
+				for generator.Generate() {
+					allocated = append(allocated, cont.Allocation{ContNum: generator.ContNum(), Time: now, Note: note})
+				}
+				return allocated, generator.Err()
+			})
+			if err != nil {
+				return err
+			}
+			// Container numbers are only written if they are recorded in the ledger.
+			for _, a := range allocated {
+				if err := writeContNum(a.ContNum); err != nil {
+					return err
+				}
+			}
+			return printer.Close()
 		},
 	}
 
@@ -155,10 +546,41 @@ Using only the --count flag generates pseudo random serial numbers.
 	generateCmd.Flags().IntVarP(&count, "count", "c", 1, "count of container numbers")
 	generateCmd.Flags().VarP(&startValue, "start", "s", "start of serial number range")
 	generateCmd.Flags().VarP(&endValue, "end", "e", "end of serial number range")
-	generateCmd.Flags().Var(&ownerValue, "owner", "custom owner code")
+	generateCmd.Flags().Var(&serialMaskValue, "serial-mask", "mask of serial numbers, e.g. 45**** or [^9]*****")
+	generateCmd.Flags().Int64Var(&seed, "seed", 0, "seed for reproducible pseudo random generation")
+	generateCmd.Flags().Var(&ownersValue, "owner", "custom owner codes, can be repeated")
+	generateCmd.Flags().StringArrayVar(&ownerFiles, "owner-file", nil,
+		"file with owner codes and optional weights, can be repeated")
+	generateCmd.Flags().StringArrayVar(&countries, "country", nil, "country of owners, can be repeated")
+	generateCmd.Flags().StringVar(&company, "company", "", "regular expression for company name of owners")
+	generateCmd.Flags().IntVar(&randomOwners, "random-owners", 0,
+		"count of random owner codes that are not registered")
+	generateCmd.Flags().IntVar(&minOwnerEdits, "min-owner-edits", 1,
+		"minimum edits between random and registered owner codes")
+	generateCmd.Flags().Var(&weightsValue, "weight", "weight of owner code, can be repeated")
+	generateCmd.Flags().Var(&equipCatValue, "equipment-category", "equipment category ID")
 	generateCmd.Flags().BoolVar(&excludeCheckDigit10, "exclude-check-digit-10", false, "exclude check digit 10")
 	generateCmd.Flags().BoolVar(&excludeTranspositionErr, "exclude-transposition-errors", false,
 		"exclude possible transposition errors")
+	generateCmd.Flags().IntVar(&minDistance, "min-distance", 0,
+		"minimum count of different positions between every pair of container numbers")
+	generateCmd.Flags().IntVar(&workers, "workers", 1, "count of workers checking candidates concurrently")
+	generateCmd.Flags().StringArrayVar(&excludeFiles, "exclude-file", nil,
+		"file with container numbers to exclude, can be repeated")
+	generateCmd.Flags().BoolVar(&sizeType, "size-type", false, "append size and type codes")
+	generateCmd.Flags().Var(&lengthsValue, "length", "length codes with optional weights, can be repeated")
+	generateCmd.Flags().Var(&heightWidthsValue, "height-width",
+		"height and width codes with optional weights, can be repeated")
+	generateCmd.Flags().Var(&groupsValue, "type-group", "type groups with optional weights, can be repeated")
+	generateCmd.Flags().Var(&outputValue, "output",
+		fmt.Sprintf("sets output to\n%s\n", generateOutputModesInfo))
+	generateCmd.Flags().StringVar(&format, "format", "", "Go template for every container number")
+	generateCmd.Flags().Bool(configs.NoHeader, configs.NoHeaderDefVal, "omits header of CSV output")
+	generateCmd.Flags().BoolVar(&allocate, "allocate", false, "record container numbers in ledger")
+	generateCmd.Flags().StringVar(&note, "note", "", "note for allocated container numbers")
+	generateCmd.Flags().BoolVar(&invalid, "invalid", false, "generate invalid container numbers tagged with a defect")
+	generateCmd.Flags().Var(&defectsValue, "defect",
+		fmt.Sprintf("defects with optional weights, can be repeated\n%s\n", defectsInfo))
 
 	generateCmd.Flags().String(configs.SepOE, configs.SepOEDefVal,
 		"ABC(*)U1234560  (*) separates owner code and equipment category id")
@@ -166,6 +588,187 @@ Using only the --count flag generates pseudo random serial numbers.
 		"ABCU(*)1234560  (*) separates equipment category id and serial number")
 	generateCmd.Flags().String(configs.SepSC, configs.SepSCDefVal,
 		"ABCU123456(*)0  (*) separates serial number and check digit")
+	generateCmd.Flags().String(configs.SepCS, configs.SepCSDefVal,
+		"ABCU1234560(*)20G1  (*) separates check digit and size")
+	generateCmd.Flags().String(configs.SepST, configs.SepSTDefVal,
+		"ABCU1234560 20(*)G1  (*) separates size and type")
 
 	return generateCmd
 }
+
+// Streams of random values derived from the seed. Serial numbers are selected
+// with the seed itself. Every other consumer of random values uses its own
+// stream, so its values are independent of the serial number selection.
+const (
+	seedStreamSizeType uint64 = iota + 1
+	seedStreamDefect
+	seedStreamUnregisteredOwner
+)
+
+// subSeed returns the seed of a stream derived from seed.
+func subSeed(seed int64, stream uint64) int64 {
+	return int64(uint64(seed) ^ stream*0x9E3779B97F4A7C15)
+}
+
+// newSizeTypeGenerator returns a generator of size and type codes. Codes with weights
+// must be known by the decoders. No weights mean all known codes with weight 1.
+func newSizeTypeGenerator(decoders sizeTypeDecoders, seed int64,
+	lengths, heightWidths, groups map[string]int) (*cont.SizeTypeGenerator, error) {
+
+	lengths, err := knownCodeWeights("length code", lengths, decoders.lengthDecoder.AllLengthCodes())
+	if err != nil {
+		return nil, err
+	}
+	heightWidths, err = knownCodeWeights("height and width code", heightWidths,
+		decoders.heightWidthDecoder.AllHeightWidthCodes())
+	if err != nil {
+		return nil, err
+	}
+	typeCodes := decoders.typeDecoder.AllTypeCodes()
+	var allGroups []string
+	for _, typeCode := range typeCodes {
+		allGroups = append(allGroups, typeCode[:1])
+	}
+	groups, err = knownCodeWeights("type group", groups, allGroups)
+	if err != nil {
+		return nil, err
+	}
+	return cont.NewSizeTypeGenerator(seed, lengths, heightWidths, groups, typeCodes)
+}
+
+// knownCodeWeights returns weights of known codes. If weights is empty every known
+// code has weight 1.
+func knownCodeWeights(name string, weights map[string]int, knownCodes []string) (map[string]int, error) {
+	known := map[string]bool{}
+	for _, code := range knownCodes {
+		known[code] = true
+	}
+	if len(weights) == 0 {
+		weights = map[string]int{}
+		for code := range known {
+			weights[code] = 1
+		}
+		return weights, nil
+	}
+	for code := range weights {
+		if !known[code] {
+			return nil, fmt.Errorf("%s %s is unknown", name, code)
+		}
+	}
+	return weights, nil
+}
+
+// readContNums reads a container number of every non-empty line of a file.
+// Check digits are ignored, so a wrong check digit does not reject a line.
+func readContNums(path string) ([]cont.Number, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var contNums []cont.Number
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		if toPattern(scanner.Text()) == "" {
+			continue
+		}
+		contNum, err := cont.ParseIgnoringCheckDigit(strings.ToUpper(toPattern(scanner.Text())))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, lineNum, err)
+		}
+		contNums = append(contNums, contNum)
+	}
+	return contNums, scanner.Err()
+}
+
+// parseContNum parses a container number and ignores separators and case.
+func parseContNum(s string) (cont.Number, error) {
+	var contNum cont.Number
+	err := contNum.UnmarshalText([]byte(strings.ToUpper(toPattern(s))))
+	return contNum, err
+}
+
+// readOwners reads an owner code and an optional weight of every non-empty line of a file.
+func readOwners(path string) ([]string, map[string]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	var codes []string
+	weights := map[string]int{}
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !strings.Contains(line, " ") {
+			if err := cont.IsOwnerCode(line); err != nil {
+				return nil, nil, fmt.Errorf("%s:%d: %v", path, lineNum, err)
+			}
+			codes = append(codes, line)
+			continue
+		}
+		code, weight, err := parseCodeWeight(line, " ", cont.IsOwnerCode)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s:%d: %v", path, lineNum, err)
+		}
+		codes = append(codes, code)
+		weights[code] = weight
+	}
+	return codes, weights, scanner.Err()
+}
+
+// selectOwnerCodes returns the unique passed owner codes or all owner codes if no
+// owner code is passed. If countries or a company is passed only owner codes of
+// registered owners with matching country and company are returned.
+func selectOwnerCodes(ownerDecoder data.OwnerDecoder, codes, countries []string, company string) ([]string, error) {
+	if len(codes) == 0 {
+		codes = ownerDecoder.GetAllOwnerCodes()
+	}
+	if len(countries) == 0 && company == "" {
+		return uniqueCodes(codes), nil
+	}
+	companyRegexp, err := regexp.Compile(company)
+	if err != nil {
+		return nil, err
+	}
+	var selected []string
+	for _, code := range uniqueCodes(codes) {
+		found, owner := ownerDecoder.Decode(code)
+		if !found || !companyRegexp.MatchString(owner.Company) {
+			continue
+		}
+		if len(countries) == 0 || containsFold(countries, owner.Country) {
+			selected = append(selected, code)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, errors.New("no owner matches the country and company")
+	}
+	return selected, nil
+}
+
+func uniqueCodes(codes []string) []string {
+	seen := map[string]bool{}
+	var unique []string
+	for _, code := range codes {
+		if !seen[code] {
+			seen[code] = true
+			unique = append(unique, code)
+		}
+	}
+	return unique
+}
+
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/meyermarcel/icm/internal/cont"
+	"github.com/meyermarcel/icm/internal/data"
+)
+
+const (
+	defectCheckDigit    = "check-digit"
+	defectTransposition = "transposition"
+	defectOwner         = "owner"
+	defectCategory      = "category"
+	defectLength        = "length"
+	defectLowercase     = "lowercase"
+	defectSeparator     = "separator"
+)
+
+var defects = []string{
+	defectCheckDigit,
+	defectTransposition,
+	defectOwner,
+	defectCategory,
+	defectLength,
+	defectLowercase,
+	defectSeparator,
+}
+
+const defectsInfo = `      ` + defectCheckDigit + ` = wrong check digit
+    ` + defectTransposition + ` = two adjacent digits are swapped
+            ` + defectOwner + ` = owner code is not registered
+         ` + defectCategory + ` = equipment category ID is not known
+           ` + defectLength + ` = serial number has 5 or 7 digits
+        ` + defectLowercase + ` = owner code and equipment category ID are lower case
+        ` + defectSeparator + ` = separators are junk characters`
+
+// junkSeparators are characters used as separators by the separator defect.
+const junkSeparators = "#/_|~*+.:"
+
+func isDefect(defect string) error {
+	for _, d := range defects {
+		if d == defect {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not \n%s", defect, defectsInfo)
+}
+
+// defectGenerator makes valid container numbers invalid with a defect chosen by weight.
+type defectGenerator struct {
+	rnd          *rand.Rand
+	defects      []string
+	cumWeights   []int
+	ownerDecoder data.OwnerDecoder
+	equipCatIDs  map[string]bool
+}
+
+// newDefectGenerator returns a generator for the weights of defects. No weights
+// mean all defects with weight 1.
+func newDefectGenerator(seed int64, weights map[string]int, ownerDecoder data.OwnerDecoder,
+	equipCatDecoder data.EquipCatDecoder) *defectGenerator {
+
+	weights, _ = knownCodeWeights("defect", weights, defects)
+	dg := &defectGenerator{
+		rnd:          rand.New(rand.NewSource(seed)),
+		ownerDecoder: ownerDecoder,
+		equipCatIDs:  map[string]bool{},
+	}
+	for defect := range weights {
+		dg.defects = append(dg.defects, defect)
+	}
+	sort.Strings(dg.defects)
+	sum := 0
+	for _, defect := range dg.defects {
+		sum += weights[defect]
+		dg.cumWeights = append(dg.cumWeights, sum)
+	}
+	for _, equipCatID := range equipCatDecoder.AllCatIDs() {
+		dg.equipCatIDs[equipCatID] = true
+	}
+	return dg
+}
+
+// Generate returns the container number with a defect and the type of the defect.
+func (dg *defectGenerator) Generate(contNum cont.Number, sepOE, sepES, sepSC string) (string, string) {
+	choice := dg.rnd.Intn(dg.cumWeights[len(dg.cumWeights)-1])
+	defect := dg.defects[sort.SearchInts(dg.cumWeights, choice+1)]
+
+	ownerCode := contNum.OwnerCode()
+	equipCatID := contNum.EquipCatID()
+	serialNum := contNum.SerialNumber()
+	checkDigit := strconv.Itoa(contNum.CheckDigit())
+
+	switch defect {
+	case defectCheckDigit:
+		checkDigit = dg.wrongCheckDigit(contNum.CheckDigit())
+	case defectTransposition:
+		chars := []byte(serialNum + checkDigit)
+		var positions []int
+		for pos := 0; pos < len(chars)-1; pos++ {
+			if chars[pos] != chars[pos+1] {
+				positions = append(positions, pos)
+			}
+		}
+		if len(positions) == 0 {
+			// All digits are equal and only a wrong check digit is possible.
+			defect = defectCheckDigit
+			checkDigit = dg.wrongCheckDigit(contNum.CheckDigit())
+			break
+		}
+		pos := positions[dg.rnd.Intn(len(positions))]
+		chars[pos], chars[pos+1] = chars[pos+1], chars[pos]
+		serialNum, checkDigit = string(chars[:6]), string(chars[6])
+	case defectOwner:
+		ownerCode = newUnregisteredOwnerCode(dg.rnd, dg.ownerDecoder)
+		checkDigit = strconv.Itoa(cont.CalcCheckDigit(ownerCode, equipCatID, serialNum) % 10)
+	case defectCategory:
+		for dg.equipCatIDs[equipCatID] {
+			equipCatID = string(rune('A' + dg.rnd.Intn(26)))
+		}
+		checkDigit = strconv.Itoa(cont.CalcCheckDigit(ownerCode, equipCatID, serialNum) % 10)
+	case defectLength:
+		pos := dg.rnd.Intn(len(serialNum))
+		if dg.rnd.Intn(2) == 0 {
+			serialNum = serialNum[:pos] + serialNum[pos+1:]
+		} else {
+			serialNum = serialNum[:pos] + strconv.Itoa(dg.rnd.Intn(10)) + serialNum[pos:]
+		}
+	case defectLowercase:
+		ownerCode = strings.ToLower(ownerCode)
+		equipCatID = strings.ToLower(equipCatID)
+	case defectSeparator:
+		sepOE, sepES, sepSC = dg.junkSeparator(), dg.junkSeparator(), dg.junkSeparator()
+	}
+	return ownerCode + sepOE + equipCatID + sepES + serialNum + sepSC + checkDigit, defect
+}
+
+// wrongCheckDigit returns a random digit other than the check digit.
+func (dg *defectGenerator) wrongCheckDigit(checkDigit int) string {
+	return strconv.Itoa((checkDigit + 1 + dg.rnd.Intn(9)) % 10)
+}
+
+func (dg *defectGenerator) junkSeparator() string {
+	return string(junkSeparators[dg.rnd.Intn(len(junkSeparators))])
+}
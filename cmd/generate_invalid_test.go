@@ -0,0 +1,83 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/meyermarcel/icm/internal/cont"
+)
+
+func Test_defectGenerator(t *testing.T) {
+	isWrongCheckDigit := func(parts []string) bool {
+		checkDigit, _ := strconv.Atoi(parts[3])
+		return cont.CalcCheckDigit(parts[0], parts[1], parts[2])%10 != checkDigit
+	}
+	tests := []struct {
+		defect    string
+		isDefect  func(parts []string) bool
+		separated bool
+	}{
+		{defectCheckDigit, isWrongCheckDigit, true},
+		{defectTransposition, isWrongCheckDigit, true},
+		{defectOwner, func(parts []string) bool {
+			found, _ := dummyOwnerDecoder{}.Decode(parts[0])
+			return !found && !isWrongCheckDigit(parts)
+		}, true},
+		{defectCategory, func(parts []string) bool {
+			return parts[1] != "U" && cont.IsEquipCatID(parts[1]) == nil && !isWrongCheckDigit(parts)
+		}, true},
+		{defectLength, func(parts []string) bool {
+			return len(parts[2]) == 5 || len(parts[2]) == 7
+		}, true},
+		{defectLowercase, func(parts []string) bool {
+			return parts[0] == "abc" && parts[1] == "u"
+		}, true},
+		{defectSeparator, func(parts []string) bool {
+			return len(parts) == 1 && strings.IndexFunc(parts[0], unicode.IsSpace) == -1
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.defect, func(t *testing.T) {
+			generator, err := cont.NewUniqueGeneratorBuilder().
+				Seed(1).
+				OwnerCodes([]string{"ABC"}).
+				Count(100).
+				ExcludeTranspositionErr(true).
+				Build()
+			if err != nil {
+				t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+			}
+			dg := newDefectGenerator(1, map[string]int{tt.defect: 1}, &dummyOwnerDecodeUpdater{},
+				&dummyEquipCatDecoder{})
+			for generator.Generate() {
+				invalid, defect := dg.Generate(generator.ContNum(), " ", " ", " ")
+				if defect != tt.defect {
+					t.Fatalf("defectGenerator.Generate() defect = %v, want %v", defect, tt.defect)
+				}
+				parts := strings.Split(invalid, " ")
+				if tt.separated && len(parts) != 4 {
+					t.Fatalf("defectGenerator.Generate() = %v, want 4 parts", invalid)
+				}
+				if !tt.isDefect(parts) {
+					t.Errorf("defectGenerator.Generate() = %v of %v has no %v defect",
+						invalid, generator.ContNum(), tt.defect)
+				}
+			}
+		})
+	}
+}
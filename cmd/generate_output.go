@@ -0,0 +1,276 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/meyermarcel/icm/internal/cont"
+	"github.com/meyermarcel/icm/internal/data"
+)
+
+const (
+	generateOutputPlain  = "plain"
+	generateOutputCSV    = "csv"
+	generateOutputJSON   = "json"
+	generateOutputNDJSON = "ndjson"
+)
+
+const generateOutputModesInfo = `  ` + generateOutputPlain + ` = container number with separators
+    ` + generateOutputCSV + ` = machine readable CSV output
+   ` + generateOutputJSON + ` = JSON array of objects
+ ` + generateOutputNDJSON + ` = JSON object per line`
+
+type generateOutputValue struct {
+	value string
+}
+
+func (o *generateOutputValue) String() string {
+	return o.value
+}
+
+func (o *generateOutputValue) Set(value string) error {
+	switch value {
+	case generateOutputPlain, generateOutputCSV, generateOutputJSON, generateOutputNDJSON:
+		o.value = value
+		return nil
+	}
+	return fmt.Errorf("%s is not \n%s", value, generateOutputModesInfo)
+}
+
+func (*generateOutputValue) Type() string {
+	return "string"
+}
+
+// generated is a generated container number with information about its owner.
+// The exported fields are available in templates of the --format flag.
+type generated struct {
+	Number            string `json:"container-number"`
+	Owner             string `json:"owner-code"`
+	Company           string `json:"company"`
+	City              string `json:"city"`
+	Country           string `json:"country"`
+	Category          string `json:"equipment-category-id"`
+	Serial            string `json:"serial-number"`
+	CheckDigit        int    `json:"check-digit"`
+	TranspositionRisk bool   `json:"transposition-risk"`
+	Length            string `json:"length-code,omitempty"`
+	HeightWidth       string `json:"height-width-code,omitempty"`
+	Type              string `json:"type-code,omitempty"`
+	Invalid           string `json:"invalid-container-number,omitempty"`
+	Defect            string `json:"defect,omitempty"`
+}
+
+func newGenerated(contNum cont.Number, ownerDecoder data.OwnerDecoder) generated {
+	_, owner := ownerDecoder.Decode(contNum.OwnerCode())
+	return generated{
+		Number:     contNum.String(),
+		Owner:      contNum.OwnerCode(),
+		Company:    owner.Company,
+		City:       owner.City,
+		Country:    owner.Country,
+		Category:   contNum.EquipCatID(),
+		Serial:     contNum.SerialNumber(),
+		CheckDigit: contNum.CheckDigit(),
+		TranspositionRisk: len(cont.CheckTransposition(
+			contNum.OwnerCode(), contNum.EquipCatID(), contNum.SerialNumber())) > 0,
+	}
+}
+
+// generatedPrinter prints generated container numbers. Close must be called after
+// the last generated container number.
+type generatedPrinter interface {
+	Print(g generated) error
+	Close() error
+	// detailed returns true if the printer prints more than the container number,
+	// size and type and defect.
+	detailed() bool
+}
+
+type plainPrinter struct {
+	writer io.Writer
+	sepCS  string
+	sepST  string
+}
+
+func (p *plainPrinter) Print(g generated) error {
+	marking := g.Number
+	if g.Invalid != "" {
+		marking = g.Invalid
+	}
+	if g.Type != "" {
+		marking += p.sepCS + g.Length + g.HeightWidth + p.sepST + g.Type
+	}
+	if g.Defect != "" {
+		marking += "\t" + g.Defect
+	}
+	_, err := io.WriteString(p.writer, marking+"\n")
+	return err
+}
+
+func (*plainPrinter) Close() error {
+	return nil
+}
+
+func (*plainPrinter) detailed() bool {
+	return false
+}
+
+type generatedCSVPrinter struct {
+	csvWriter     *csv.Writer
+	noHeader      bool
+	sizeType      bool
+	invalid       bool
+	headerPrinted bool
+}
+
+func (p *generatedCSVPrinter) Print(g generated) error {
+	if !p.noHeader && !p.headerPrinted {
+		headers := []string{"owner-code", "company", "city", "country", "equipment-category-id",
+			"serial-number", "check-digit", "transposition-risk"}
+		if p.sizeType {
+			headers = append(headers, "length-code", "height-width-code", "type-code")
+		}
+		if p.invalid {
+			headers = append(headers, "invalid-container-number", "defect")
+		}
+		if err := p.csvWriter.Write(headers); err != nil {
+			return err
+		}
+		p.headerPrinted = true
+	}
+	record := []string{g.Owner, g.Company, g.City, g.Country, g.Category,
+		g.Serial, strconv.Itoa(g.CheckDigit), strconv.FormatBool(g.TranspositionRisk)}
+	if p.sizeType {
+		record = append(record, g.Length, g.HeightWidth, g.Type)
+	}
+	if p.invalid {
+		record = append(record, g.Invalid, g.Defect)
+	}
+	return p.csvWriter.Write(record)
+}
+
+func (p *generatedCSVPrinter) Close() error {
+	p.csvWriter.Flush()
+	return p.csvWriter.Error()
+}
+
+func (*generatedCSVPrinter) detailed() bool {
+	return true
+}
+
+type generatedJSONPrinter struct {
+	writer  io.Writer
+	printed bool
+}
+
+func (p *generatedJSONPrinter) Print(g generated) error {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(g); err != nil {
+		return err
+	}
+	prefix := ",\n  "
+	if !p.printed {
+		prefix = "[\n  "
+		p.printed = true
+	}
+	_, err := io.WriteString(p.writer, prefix+strings.TrimSuffix(buffer.String(), "\n"))
+	return err
+}
+
+func (p *generatedJSONPrinter) Close() error {
+	if !p.printed {
+		_, err := io.WriteString(p.writer, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(p.writer, "\n]\n")
+	return err
+}
+
+func (*generatedJSONPrinter) detailed() bool {
+	return true
+}
+
+type generatedNDJSONPrinter struct {
+	encoder *json.Encoder
+}
+
+func (p *generatedNDJSONPrinter) Print(g generated) error {
+	return p.encoder.Encode(g)
+}
+
+func (*generatedNDJSONPrinter) Close() error {
+	return nil
+}
+
+func (*generatedNDJSONPrinter) detailed() bool {
+	return true
+}
+
+type templatePrinter struct {
+	writer   io.Writer
+	template *template.Template
+}
+
+func (p *templatePrinter) Print(g generated) error {
+	if err := p.template.Execute(p.writer, g); err != nil {
+		return err
+	}
+	_, err := io.WriteString(p.writer, "\n")
+	return err
+}
+
+func (*templatePrinter) Close() error {
+	return nil
+}
+
+func (*templatePrinter) detailed() bool {
+	return true
+}
+
+// newGeneratedPrinter returns a printer for the output mode or for the template
+// format if it is not empty.
+func newGeneratedPrinter(writer io.Writer, output, format string, noHeader, sizeType, invalid bool,
+	sepCS, sepST string) (generatedPrinter, error) {
+	if format != "" {
+		tmpl, err := template.New("format").Parse(format)
+		if err != nil {
+			return nil, err
+		}
+		return &templatePrinter{writer: writer, template: tmpl}, nil
+	}
+	switch output {
+	case generateOutputCSV:
+		csvWriter := csv.NewWriter(writer)
+		csvWriter.Comma = ';'
+		return &generatedCSVPrinter{csvWriter: csvWriter, noHeader: noHeader, sizeType: sizeType,
+			invalid: invalid}, nil
+	case generateOutputJSON:
+		return &generatedJSONPrinter{writer: writer}, nil
+	case generateOutputNDJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetEscapeHTML(false)
+		return &generatedNDJSONPrinter{encoder: encoder}, nil
+	}
+	return &plainPrinter{writer: writer, sepCS: sepCS, sepST: sepST}, nil
+}
@@ -15,7 +15,9 @@ package cmd
 
 import (
 	"bytes"
-	"math/rand"
+	"io/ioutil"
+	"os"
+	"reflect"
 	"testing"
 
 	"github.com/meyermarcel/icm/configs"
@@ -56,6 +58,309 @@ func Test_generateCmd(t *testing.T) {
 			}},
 			false,
 			`ABC U 724553 6
+`,
+		},
+		{
+			"Generate 4 random container numbers with custom owners and weights",
+			nil,
+			[]flag{
+				{
+					name:  "owner",
+					value: "ABC,DEF",
+				},
+				{
+					name:  "owner",
+					value: "ABC",
+				},
+				{
+					name:  "weight",
+					value: "DEF=3",
+				},
+				{
+					name:  "count",
+					value: "4",
+				},
+			},
+			false,
+			`DEF U 024318 8
+DEF U 044421 7
+DEF U 104742 5
+ABC U 125529 1
+`,
+		},
+		{
+			"Generate 1 random container number with owner of country and company",
+			nil,
+			[]flag{
+				{
+					name:  "owner",
+					value: "ABC,DEF",
+				},
+				{
+					name:  "country",
+					value: "SOME-COUNTRY",
+				},
+				{
+					name:  "company",
+					value: "^some-",
+				},
+			},
+			false,
+			`ABC U 724553 6
+`,
+		},
+		{
+			"Generate no container number for unmatched company",
+			nil,
+			[]flag{{
+				name:  "company",
+				value: "^other-",
+			}},
+			true,
+			"",
+		},
+		{
+			"Generate 3 random container numbers with size and type",
+			nil,
+			[]flag{
+				{
+					name:  "count",
+					value: "3",
+				},
+				{
+					name:  "size-type",
+					value: "true",
+				},
+			},
+			false,
+			`RAN U 724553 3   25 R1
+RAN U 165715 3   22 G1
+RAN U 489155 0   25 U1
+`,
+		},
+		{
+			"Generate 3 random container numbers with restricted size and type and custom separators",
+			[]cfgOverride{
+				{
+					name:  configs.SepCS,
+					value: "-",
+				},
+				{
+					name:  configs.SepST,
+					value: "",
+				},
+			},
+			[]flag{
+				{
+					name:  "count",
+					value: "3",
+				},
+				{
+					name:  "length",
+					value: "4",
+				},
+				{
+					name:  "height-width",
+					value: "2=1,5=9",
+				},
+				{
+					name:  "type-group",
+					value: "R",
+				},
+			},
+			false,
+			`RAN U 724553 3-45R1
+RAN U 165715 3-42R1
+RAN U 489155 0-45R1
+`,
+		},
+		{
+			"Generate no container number for unknown length code",
+			nil,
+			[]flag{{
+				name:  "length",
+				value: "9",
+			}},
+			true,
+			"",
+		},
+		{
+			"Generate 3 container numbers with serial mask and start",
+			nil,
+			[]flag{
+				{
+					name:  "count",
+					value: "3",
+				},
+				{
+					name:  "start",
+					value: "1",
+				},
+				{
+					name:  "serial-mask",
+					value: "[^9]****[05]",
+				},
+			},
+			false,
+			`RAN U 000005 6
+RAN U 000010 1
+RAN U 000015 9
+`,
+		},
+		{
+			"Generate 3 container numbers with minimum distance in range",
+			nil,
+			[]flag{
+				{
+					name:  "count",
+					value: "3",
+				},
+				{
+					name:  "start",
+					value: "0",
+				},
+				{
+					name:  "end",
+					value: "999",
+				},
+				{
+					name:  "min-distance",
+					value: "3",
+				},
+			},
+			false,
+			`RAN U 000000 9
+RAN U 000011 7
+RAN U 000022 5
+`,
+		},
+		{
+			"Generate no container number for not achievable minimum distance",
+			nil,
+			[]flag{
+				{
+					name:  "count",
+					value: "3",
+				},
+				{
+					name:  "start",
+					value: "0",
+				},
+				{
+					name:  "end",
+					value: "9",
+				},
+				{
+					name:  "min-distance",
+					value: "3",
+				},
+			},
+			true,
+			"",
+		},
+		{
+			"Generate 2 container numbers as CSV",
+			nil,
+			[]flag{
+				{name: "owner", value: "ABC"},
+				{name: "count", value: "2"},
+				{name: "start", value: "1"},
+				{name: "output", value: "csv"},
+			},
+			false,
+			`owner-code;company;city;country;equipment-category-id;serial-number;check-digit;transposition-risk
+ABC;some-company;some-city;some-country;U;000001;7;false
+ABC;some-company;some-city;some-country;U;000002;2;false
+`,
+		},
+		{
+			"Generate 2 container numbers as CSV with size and type without header",
+			nil,
+			[]flag{
+				{name: "owner", value: "ABC"},
+				{name: "count", value: "2"},
+				{name: "start", value: "1"},
+				{name: "output", value: "csv"},
+				{name: "size-type", value: "true"},
+				{name: "no-header", value: "true"},
+			},
+			false,
+			`ABC;some-company;some-city;some-country;U;000001;7;false;2;5;R1
+ABC;some-company;some-city;some-country;U;000002;2;false;2;2;G1
+`,
+		},
+		{
+			"Generate 2 container numbers as JSON",
+			nil,
+			[]flag{
+				{name: "owner", value: "ABC"},
+				{name: "count", value: "2"},
+				{name: "start", value: "1"},
+				{name: "output", value: "json"},
+			},
+			false,
+			`[
+  {"container-number":"ABC U 000001 7","owner-code":"ABC","company":"some-company","city":"some-city","country":"some-country","equipment-category-id":"U","serial-number":"000001","check-digit":7,"transposition-risk":false},
+  {"container-number":"ABC U 000002 2","owner-code":"ABC","company":"some-company","city":"some-city","country":"some-country","equipment-category-id":"U","serial-number":"000002","check-digit":2,"transposition-risk":false}
+]
+`,
+		},
+		{
+			"Generate 2 container numbers as NDJSON",
+			nil,
+			[]flag{
+				{name: "owner", value: "ABC"},
+				{name: "count", value: "2"},
+				{name: "start", value: "1"},
+				{name: "output", value: "ndjson"},
+			},
+			false,
+			`{"container-number":"ABC U 000001 7","owner-code":"ABC","company":"some-company","city":"some-city","country":"some-country","equipment-category-id":"U","serial-number":"000001","check-digit":7,"transposition-risk":false}
+{"container-number":"ABC U 000002 2","owner-code":"ABC","company":"some-company","city":"some-city","country":"some-country","equipment-category-id":"U","serial-number":"000002","check-digit":2,"transposition-risk":false}
+`,
+		},
+		{
+			"Generate 2 container numbers with template format",
+			nil,
+			[]flag{
+				{name: "owner", value: "ABC"},
+				{name: "count", value: "2"},
+				{name: "start", value: "1"},
+				{name: "format", value: "{{.Owner}}-{{.Serial}}-{{.CheckDigit}} {{.Company}}"},
+			},
+			false,
+			`ABC-000001-7 some-company
+ABC-000002-2 some-company
+`,
+		},
+		{
+			"Generate no container number for format and output",
+			nil,
+			[]flag{
+				{name: "format", value: "{{.Owner}}"},
+				{name: "output", value: "csv"},
+			},
+			true,
+			"",
+		},
+		{
+			"Generate no container number for invalid template",
+			nil,
+			[]flag{
+				{name: "format", value: "{{.Owner"},
+			},
+			true,
+			"",
+		},
+		{
+			"Generate 1 random container number with custom equipment category",
+			nil,
+			[]flag{{
+				name:  "equipment-category",
+				value: "J",
+			}},
+			false,
+			`RAN J 724553 6
 `,
 		},
 		{
@@ -172,6 +477,177 @@ RAN U 000002 0
 			`RAN***U+++724553‧‧‧3
 `,
 		},
+		{
+			"Generate invalid container numbers",
+			nil,
+			[]flag{
+				{
+					name:  "count",
+					value: "6",
+				},
+				{
+					name:  "start",
+					value: "1",
+				},
+				{
+					name:  "owner",
+					value: "ABC",
+				},
+				{
+					name:  "defect",
+					value: "check-digit,lowercase",
+				},
+			},
+			false,
+			`ABC U 000001 3	check-digit
+abc u 000002 2	lowercase
+ABC U 000003 6	check-digit
+abc u 000004 3	lowercase
+ABC U 000005 2	check-digit
+ABC U 000006 8	check-digit
+`,
+		},
+		{
+			"Generate invalid container numbers with allocate returns error",
+			nil,
+			[]flag{
+				{
+					name:  "invalid",
+					value: "true",
+				},
+				{
+					name:  "allocate",
+					value: "true",
+				},
+			},
+			true,
+			"",
+		},
+		{
+			"Generate container numbers of random unregistered owners",
+			nil,
+			[]flag{
+				{
+					name:  "count",
+					value: "2",
+				},
+				{
+					name:  "random-owners",
+					value: "2",
+				},
+				{
+					name:  "min-owner-edits",
+					value: "3",
+				},
+			},
+			false,
+			`TOY U 724553 8
+TOY U 165715 8
+`,
+		},
+		{
+			"Generate with random owners and owner returns error",
+			nil,
+			[]flag{
+				{
+					name:  "random-owners",
+					value: "1",
+				},
+				{
+					name:  "owner",
+					value: "ABC",
+				},
+			},
+			true,
+			"",
+		},
+		{
+			"Generate 3 container numbers with workers",
+			nil,
+			[]flag{
+				{
+					name:  "count",
+					value: "3",
+				},
+				{
+					name:  "start",
+					value: "1",
+				},
+				{
+					name:  "workers",
+					value: "4",
+				},
+			},
+			false,
+			`RAN U 000001 4
+RAN U 000002 0
+RAN U 000003 5
+`,
+		},
+		{
+			"Generate range with check digit 10 excluded stops at end",
+			nil,
+			[]flag{
+				{
+					name:  "owner",
+					value: "CSQ",
+				},
+				{
+					name:  "start",
+					value: "5",
+				},
+				{
+					name:  "end",
+					value: "9",
+				},
+				{
+					name:  "exclude-check-digit-10",
+					value: "true",
+				},
+			},
+			false,
+			`CSQ U 000005 9
+CSQ U 000006 4
+CSQ U 000008 5
+CSQ U 000009 0
+`,
+		},
+		{
+			"Generate more than available container numbers returns error",
+			nil,
+			[]flag{
+				{
+					name:  "owner",
+					value: "CSQ",
+				},
+				{
+					name:  "serial-mask",
+					value: "00000[5-7]",
+				},
+				{
+					name:  "count",
+					value: "3",
+				},
+				{
+					name:  "exclude-transposition-errors",
+					value: "true",
+				},
+			},
+			true,
+			`CSQ U 000006 4
+CSQ U 000005 9
+`,
+		},
+		{
+			"Generate with 0 workers returns error",
+			nil,
+			[]flag{{
+				name:  "workers",
+				value: "0",
+			}},
+			true,
+			"",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -181,12 +657,12 @@ RAN U 000002 0
 			for _, override := range tt.cfgOverrides {
 				viperCfg.Set(override.name, override.value)
 			}
-			cmd := newGenerateCmd(writer, writerErr, viperCfg, &dummyOwnerDecodeUpdater{})
+			cmd := newGenerateCmd(writer, writerErr, viperCfg, newDummyDecoders(), &dummyLedger{})
 			for _, flag := range tt.flags {
 				_ = cmd.Flags().Set(flag.name, flag.value)
 			}
+			_ = cmd.Flags().Set("seed", "1")
 			_ = cmd.PreRunE(cmd, nil)
-			rand.Seed(1)
 			if got := cmd.RunE(cmd, nil); (got == nil) == tt.wantErr {
 				t.Errorf("got = %v, wantErr is %v", got, tt.wantErr)
 			}
@@ -196,3 +672,132 @@ RAN U 000002 0
 		})
 	}
 }
+
+func Test_generateCmd_excludeFile(t *testing.T) {
+	fleet, err := ioutil.TempFile("", "fleet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(fleet.Name())
+	// The check digit of RANU000003 is 5 and a wrong check digit is ignored.
+	if _, err := fleet.WriteString("RAN U 000002 0\n\nRANU0000039\n"); err != nil {
+		t.Fatal(err)
+	}
+	fleet.Close()
+
+	writer := &bytes.Buffer{}
+	cmd := newGenerateCmd(writer, &bytes.Buffer{}, viper.New(), newDummyDecoders(), &dummyLedger{})
+	_ = cmd.Flags().Set("count", "3")
+	_ = cmd.Flags().Set("start", "1")
+	_ = cmd.Flags().Set("exclude-file", fleet.Name())
+	_ = cmd.PreRunE(cmd, nil)
+	if err := cmd.RunE(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := `RAN U 000001 4
+RAN U 000004 0
+RAN U 000005 6
+`
+	if got := writer.String(); got != want {
+		t.Errorf("got = %v, want %v", got, want)
+	}
+}
+
+func Test_readContNums_invalidLine(t *testing.T) {
+	fleet, err := ioutil.TempFile("", "fleet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(fleet.Name())
+	if _, err := fleet.WriteString("RAN U 000002 0\n\nRANU00003\n"); err != nil {
+		t.Fatal(err)
+	}
+	fleet.Close()
+
+	_, err = readContNums(fleet.Name())
+	want := fleet.Name() + ":3: RANU00003 is not 10 or 11 characters long"
+	if err == nil || err.Error() != want {
+		t.Errorf("readContNums() error = %v, want %v", err, want)
+	}
+}
+
+func Test_generateCmd_allocate(t *testing.T) {
+	ledger := &dummyLedger{}
+	for _, want := range []string{
+		"RAN U 000001 4\nRAN U 000002 0\n",
+		"RAN U 000003 5\nRAN U 000004 0\n",
+	} {
+		writer := &bytes.Buffer{}
+		cmd := newGenerateCmd(writer, &bytes.Buffer{}, viper.New(), newDummyDecoders(), ledger)
+		_ = cmd.Flags().Set("count", "2")
+		_ = cmd.Flags().Set("start", "1")
+		_ = cmd.Flags().Set("allocate", "true")
+		_ = cmd.Flags().Set("note", "some-note")
+		_ = cmd.PreRunE(cmd, nil)
+		if err := cmd.RunE(cmd, nil); err != nil {
+			t.Fatal(err)
+		}
+		if got := writer.String(); got != want {
+			t.Errorf("got = %v, want %v", got, want)
+		}
+	}
+	if len(ledger.allocations) != 4 {
+		t.Errorf("got %d allocations, want 4", len(ledger.allocations))
+	}
+	for _, a := range ledger.allocations {
+		if a.Note != "some-note" {
+			t.Errorf("got note %v, want some-note", a.Note)
+		}
+	}
+}
+
+func Test_readOwners(t *testing.T) {
+	owners, err := ioutil.TempFile("", "owners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(owners.Name())
+	if _, err := owners.WriteString("ABC\n\nDEF 3\n"); err != nil {
+		t.Fatal(err)
+	}
+	owners.Close()
+
+	codes, weights, err := readOwners(owners.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"ABC", "DEF"}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("codes = %v, want %v", codes, want)
+	}
+	if want := map[string]int{"DEF": 3}; !reflect.DeepEqual(weights, want) {
+		t.Errorf("weights = %v, want %v", weights, want)
+	}
+}
+
+func BenchmarkGenerateCmd(b *testing.B) {
+	for _, output := range []string{generateOutputPlain, generateOutputNDJSON} {
+		b.Run(output, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				cmd := newGenerateCmd(ioutil.Discard, ioutil.Discard, viper.New(), newDummyDecoders(), &dummyLedger{})
+				_ = cmd.Flags().Set("count", "100000")
+				_ = cmd.Flags().Set("seed", "1")
+				_ = cmd.Flags().Set("output", output)
+				_ = cmd.PreRunE(cmd, nil)
+				if err := cmd.RunE(cmd, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func Test_subSeed(t *testing.T) {
+	seeds := map[int64]bool{1: true}
+	for _, stream := range []uint64{seedStreamSizeType, seedStreamDefect, seedStreamUnregisteredOwner} {
+		seed := subSeed(1, stream)
+		if seeds[seed] {
+			t.Errorf("subSeed(1, %d) = %d, want seed distinct from other streams", stream, seed)
+		}
+		seeds[seed] = true
+	}
+}
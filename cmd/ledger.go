@@ -0,0 +1,129 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/meyermarcel/icm/internal/cont"
+	"github.com/meyermarcel/icm/internal/data"
+	"github.com/spf13/cobra"
+)
+
+func newLedgerCmd(stdin io.Reader, writer, writerErr io.Writer, ledger data.Ledger) *cobra.Command {
+	ledgerCmd := &cobra.Command{
+		Use:   "ledger",
+		Short: "List and release allocated container numbers",
+		Long: `List and release container numbers allocated with generate --allocate.
+Allocations are recorded in
+
+  ` + filepath.Join("$HOME", appDir, "data", "ledger.json"),
+		Example: `  icm ledger list
+  icm ledger list --owner ABC
+  icm ledger release 'ABC U 100500 1'
+  cat released.txt | icm ledger release`,
+		Args: cobra.NoArgs,
+	}
+
+	ledgerCmd.AddCommand(newLedgerListCmd(writer, ledger))
+	ledgerCmd.AddCommand(newLedgerReleaseCmd(stdin, writerErr, ledger))
+
+	return ledgerCmd
+}
+
+func newLedgerListCmd(writer io.Writer, ledger data.Ledger) *cobra.Command {
+
+	var ownerValue = ownerValue{}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List allocated container numbers",
+		Long: `List allocated container numbers with time of allocation and note.
+Columns are separated by tabs.`,
+		Example: `  icm ledger list
+  icm ledger list --owner ABC`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			allocations, err := ledger.List()
+			if err != nil {
+				return err
+			}
+			for _, a := range allocations {
+				if ownerValue.value != "" && a.ContNum.OwnerCode() != ownerValue.value {
+					continue
+				}
+				if _, err := io.WriteString(writer,
+					fmt.Sprintf("%s\t%s\t%s\n", a.ContNum, a.Time.Format(time.RFC3339), a.Note)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	listCmd.Flags().Var(&ownerValue, "owner", "list only allocations of owner code")
+
+	return listCmd
+}
+
+func newLedgerReleaseCmd(stdin io.Reader, writerErr io.Writer, ledger data.Ledger) *cobra.Command {
+	releaseCmd := &cobra.Command{
+		Use:   "release",
+		Short: "Release allocated container numbers",
+		Long: `Release allocated container numbers. Released container numbers can be
+allocated again. Every argument is a container number. If no argument is
+passed every line of stdin is a container number.`,
+		Example: `  icm ledger release 'ABC U 100500 1'
+  icm ledger release ABCU1005001 ABCU1005017
+  cat released.txt | icm ledger release`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lines := args
+			if len(args) == 0 {
+				scanner := bufio.NewScanner(stdin)
+				for scanner.Scan() {
+					if strings.TrimSpace(scanner.Text()) != "" {
+						lines = append(lines, scanner.Text())
+					}
+				}
+				if err := scanner.Err(); err != nil {
+					return err
+				}
+			}
+
+			contNums := make([]cont.Number, 0, len(lines))
+			for _, line := range lines {
+				contNum, err := parseContNum(line)
+				if err != nil {
+					return err
+				}
+				contNums = append(contNums, contNum)
+			}
+
+			notAllocated, err := ledger.Release(contNums)
+			if err != nil {
+				return err
+			}
+			for _, contNum := range notAllocated {
+				writeErr(writerErr, fmt.Errorf("%s is not allocated", contNum))
+			}
+			return nil
+		},
+	}
+	return releaseCmd
+}
@@ -0,0 +1,125 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meyermarcel/icm/internal/cont"
+)
+
+func newDummyAllocations(t *testing.T, contNums ...string) []cont.Allocation {
+	var allocations []cont.Allocation
+	for _, s := range contNums {
+		contNum, err := parseContNum(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		allocations = append(allocations, cont.Allocation{
+			ContNum: contNum,
+			Time:    time.Date(2018, 10, 29, 15, 0, 0, 0, time.UTC),
+			Note:    "some-note",
+		})
+	}
+	return allocations
+}
+
+func Test_ledgerListCmd(t *testing.T) {
+	tests := []struct {
+		name       string
+		owner      string
+		wantWriter string
+	}{
+		{
+			"List all allocations",
+			"",
+			`ABC U 000001 7	2018-10-29T15:00:00Z	some-note
+DEF U 000002 2	2018-10-29T15:00:00Z	some-note
+`,
+		},
+		{
+			"List allocations of owner",
+			"DEF",
+			`DEF U 000002 2	2018-10-29T15:00:00Z	some-note
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledger := &dummyLedger{allocations: newDummyAllocations(t, "ABCU0000017", "DEFU0000022")}
+			writer := &bytes.Buffer{}
+			cmd := newLedgerListCmd(writer, ledger)
+			if tt.owner != "" {
+				_ = cmd.Flags().Set("owner", tt.owner)
+			}
+			if err := cmd.RunE(cmd, nil); err != nil {
+				t.Fatal(err)
+			}
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+		})
+	}
+}
+
+func Test_ledgerReleaseCmd(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		stdin         string
+		wantRemaining int
+		wantWriterErr string
+	}{
+		{
+			"Release container number of argument",
+			[]string{"abc-u-000001-7"},
+			"",
+			1,
+			"",
+		},
+		{
+			"Release container numbers of stdin",
+			nil,
+			"ABC U 000001 7\n\nDEF U 000002 2\n",
+			0,
+			"",
+		},
+		{
+			"Release not allocated container number",
+			[]string{"ABCU0000022"},
+			"",
+			2,
+			"icm: ABC U 000002 2 is not allocated\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledger := &dummyLedger{allocations: newDummyAllocations(t, "ABCU0000017", "DEFU0000022")}
+			writerErr := &bytes.Buffer{}
+			cmd := newLedgerReleaseCmd(strings.NewReader(tt.stdin), writerErr, ledger)
+			if err := cmd.RunE(cmd, tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if got := len(ledger.allocations); got != tt.wantRemaining {
+				t.Errorf("got %d remaining allocations, want %d", got, tt.wantRemaining)
+			}
+			if gotWriterErr := writerErr.String(); gotWriterErr != tt.wantWriterErr {
+				t.Errorf("gotWriterErr = %v, want %v", gotWriterErr, tt.wantWriterErr)
+			}
+		})
+	}
+}
@@ -99,6 +99,8 @@ func Execute(version string) {
 	timestampUpdater, err := file.NewTimestampUpdater(appDirDataPath)
 	checkErr(stderr, err)
 
+	ledger := file.NewLedger(appDirDataPath)
+
 	bufWriter := bufio.NewWriter(os.Stdout)
 	rootCmd := newRootCmd(
 		version,
@@ -114,6 +116,7 @@ func Execute(version string) {
 				typeDecoder},
 		},
 		timestampUpdater,
+		ledger,
 		ownerURL)
 
 	errCmd := rootCmd.Execute()
@@ -131,6 +134,7 @@ func newRootCmd(
 	viper *viper.Viper,
 	decoders decoders,
 	timestampUpdater data.TimestampUpdater,
+	ledger data.Ledger,
 	ownerURL string) *cobra.Command {
 	rootCmd := &cobra.Command{
 		Version:       version,
@@ -150,8 +154,11 @@ func newRootCmd(
 		},
 	}
 
-	rootCmd.AddCommand(newGenerateCmd(writer, writerErr, viper, decoders.ownerDecodeUpdater))
-	rootCmd.AddCommand(newValidateCmd(os.Stdin, writer, viper, decoders))
+	rootCmd.AddCommand(newGenerateCmd(writer, writerErr, viper, decoders, ledger))
+	rootCmd.AddCommand(newValidateCmd(os.Stdin, writer, writerErr, viper, decoders))
+	rootCmd.AddCommand(newCompleteCmd(os.Stdin, writer, writerErr, viper, decoders))
+	rootCmd.AddCommand(newLedgerCmd(os.Stdin, writer, writerErr, ledger))
+	rootCmd.AddCommand(newAnalyzeCmd(writer, decoders))
 	rootCmd.AddCommand(newUpdateOwnerCmd(decoders.ownerDecodeUpdater, timestampUpdater, ownerURL))
 	rootCmd.AddCommand(newMiscCmd(writer, rootCmd))
 
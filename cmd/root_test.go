@@ -15,6 +15,18 @@ package cmd
 
 import "github.com/meyermarcel/icm/internal/cont"
 
+func newDummyDecoders() decoders {
+	return decoders{
+		ownerDecodeUpdater: &dummyOwnerDecodeUpdater{},
+		equipCatDecoder:    &dummyEquipCatDecoder{},
+		sizeTypeDecoders: sizeTypeDecoders{
+			&dummyLengthDecoder{},
+			&dummyHeightWidthDecoder{},
+			&dummyTypeDecoder{},
+		},
+	}
+}
+
 type dummyOwnerDecodeUpdater struct {
 	dummyOwnerDecoder
 	dummyOwnerUpdater
@@ -69,6 +81,10 @@ func (dummyLengthDecoder) Decode(code string) (bool, cont.Length) {
 	}
 }
 
+func (dummyLengthDecoder) AllLengthCodes() []string {
+	return []string{"2", "4"}
+}
+
 type dummyHeightWidthDecoder struct {
 }
 
@@ -79,6 +95,10 @@ func (dummyHeightWidthDecoder) Decode(code string) (bool, cont.HeightWidth) {
 	}
 }
 
+func (dummyHeightWidthDecoder) AllHeightWidthCodes() []string {
+	return []string{"2", "5"}
+}
+
 type dummyTypeDecoder struct {
 }
 
@@ -94,3 +114,42 @@ func (dummyTypeDecoder) Decode(code string) (bool, cont.TypeAndGroup) {
 		},
 	}
 }
+
+func (dummyTypeDecoder) AllTypeCodes() []string {
+	return []string{"G1", "R1", "U1"}
+}
+
+type dummyLedger struct {
+	allocations []cont.Allocation
+}
+
+func (l *dummyLedger) Allocate(allocate func(allocations []cont.Allocation) ([]cont.Allocation, error)) error {
+	newAllocations, err := allocate(l.allocations)
+	if err != nil {
+		return err
+	}
+	l.allocations = append(l.allocations, newAllocations...)
+	return nil
+}
+
+func (l *dummyLedger) List() ([]cont.Allocation, error) {
+	return l.allocations, nil
+}
+
+func (l *dummyLedger) Release(contNums []cont.Number) ([]cont.Number, error) {
+	var notAllocated []cont.Number
+	for _, contNum := range contNums {
+		released := false
+		for i, a := range l.allocations {
+			if a.ContNum.String() == contNum.String() {
+				l.allocations = append(l.allocations[:i], l.allocations[i+1:]...)
+				released = true
+				break
+			}
+		}
+		if !released {
+			notAllocated = append(notAllocated, contNum)
+		}
+	}
+	return notAllocated, nil
+}
@@ -0,0 +1,33 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/mattn/go-isatty"
+)
+
+// terminalWidth returns the count of columns of the terminal. The environment
+// variable COLUMNS overrides the width. It returns 0 if stdout is no terminal.
+func terminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil {
+		return columns
+	}
+	if !isatty.IsTerminal(os.Stdout.Fd()) {
+		return 0
+	}
+	return ttyWidth(os.Stdout.Fd())
+}
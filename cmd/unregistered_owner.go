@@ -0,0 +1,115 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/meyermarcel/icm/internal/cont"
+	"github.com/meyermarcel/icm/internal/data"
+)
+
+// ownerCodesLen is the count of all owner codes of three upper case letters.
+const ownerCodesLen = 26 * 26 * 26
+
+// maxOwnerEdits is the maximum edit distance between two owner codes.
+const maxOwnerEdits = 3
+
+// newUnregisteredOwnerCode returns a random owner code that is not registered.
+func newUnregisteredOwnerCode(rnd *rand.Rand, ownerDecoder data.OwnerDecoder) string {
+	for {
+		code := make([]byte, 3)
+		for i := range code {
+			code[i] = byte('A' + rnd.Intn(26))
+		}
+		if found, _ := ownerDecoder.Decode(string(code)); !found {
+			return string(code)
+		}
+	}
+}
+
+// newUnregisteredOwnerCodes returns count random owner codes that are not registered
+// and differ from every registered owner code in at least minEdits edits. An edit
+// is a substitution, insertion or deletion of a letter.
+func newUnregisteredOwnerCodes(rnd *rand.Rand, ownerDecoder data.OwnerDecoder, count, minEdits int) ([]string, error) {
+	if minEdits < 1 || minEdits > maxOwnerEdits {
+		return nil, fmt.Errorf("minimum edits %d is not in range from 1 to %d", minEdits, maxOwnerEdits)
+	}
+	var near [ownerCodesLen]bool
+	for _, code := range ownerDecoder.GetAllOwnerCodes() {
+		if cont.IsOwnerCode(code) != nil {
+			continue
+		}
+		var letters [3]byte
+		copy(letters[:], code)
+		markNearOwnerCodes(&near, letters, minEdits-1)
+	}
+
+	var codes []string
+	for idx := range near {
+		code := ownerCodeOfIdx(idx)
+		if found, _ := ownerDecoder.Decode(code); !near[idx] && !found {
+			codes = append(codes, code)
+		}
+	}
+	if count > len(codes) {
+		return nil, fmt.Errorf("count %d exceeds %d unregistered owner codes with minimum edits %d",
+			count, len(codes), minEdits)
+	}
+	rnd.Shuffle(len(codes), func(i, j int) {
+		codes[i], codes[j] = codes[j], codes[i]
+	})
+	return codes[:count], nil
+}
+
+// markNearOwnerCodes marks all owner codes within edits edits of the owner code.
+// Between owner codes of the same length two edits are two substitutions or a
+// deletion followed by an insertion. Three edits reach every owner code.
+func markNearOwnerCodes(near *[ownerCodesLen]bool, code [3]byte, edits int) {
+	near[ownerCodeIdx(code)] = true
+	if edits < 1 {
+		return
+	}
+	for pos := range code {
+		substituted := code
+		for letter := byte('A'); letter <= 'Z'; letter++ {
+			substituted[pos] = letter
+			markNearOwnerCodes(near, substituted, edits-1)
+		}
+	}
+	if edits < 2 {
+		return
+	}
+	for del := range code {
+		deleted := append(append([]byte{}, code[:del]...), code[del+1:]...)
+		for ins := 0; ins <= len(deleted); ins++ {
+			var inserted [3]byte
+			copy(inserted[:ins], deleted[:ins])
+			copy(inserted[ins+1:], deleted[ins:])
+			for letter := byte('A'); letter <= 'Z'; letter++ {
+				inserted[ins] = letter
+				near[ownerCodeIdx(inserted)] = true
+			}
+		}
+	}
+}
+
+func ownerCodeIdx(code [3]byte) int {
+	return (int(code[0]-'A')*26+int(code[1]-'A'))*26 + int(code[2]-'A')
+}
+
+func ownerCodeOfIdx(idx int) string {
+	return string([]byte{byte('A' + idx/(26*26)), byte('A' + idx/26%26), byte('A' + idx%26)})
+}
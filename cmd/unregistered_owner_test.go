@@ -0,0 +1,93 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func Test_newUnregisteredOwnerCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		minEdits int
+		maxCount int
+	}{
+		{"Unregistered owner codes", 1, 17574},
+		{"Unregistered owner codes with minimum edits 2", 2, 17499},
+		{"Unregistered owner codes with minimum edits 3", 3, 15574},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codes, err := newUnregisteredOwnerCodes(rand.New(rand.NewSource(1)), &dummyOwnerDecodeUpdater{},
+				tt.maxCount, tt.minEdits)
+			if err != nil {
+				t.Fatalf("newUnregisteredOwnerCodes() error = %v", err)
+			}
+			seen := map[string]bool{}
+			for _, code := range codes {
+				if seen[code] {
+					t.Errorf("newUnregisteredOwnerCodes() returned %v twice", code)
+				}
+				seen[code] = true
+				if code == "ABC" {
+					t.Errorf("newUnregisteredOwnerCodes() returned registered owner code %v", code)
+				}
+				if edits := levenshtein(code, "RAN"); edits < tt.minEdits {
+					t.Errorf("newUnregisteredOwnerCodes() returned %v with %d edits to RAN, want at least %d",
+						code, edits, tt.minEdits)
+				}
+			}
+			if _, err := newUnregisteredOwnerCodes(rand.New(rand.NewSource(1)), &dummyOwnerDecodeUpdater{},
+				tt.maxCount+1, tt.minEdits); err == nil {
+				t.Errorf("newUnregisteredOwnerCodes() error = nil, want error for count %d", tt.maxCount+1)
+			}
+		})
+	}
+}
+
+func Test_newUnregisteredOwnerCodesErr(t *testing.T) {
+	for _, minEdits := range []int{0, 4} {
+		if _, err := newUnregisteredOwnerCodes(rand.New(rand.NewSource(1)), &dummyOwnerDecodeUpdater{},
+			1, minEdits); err == nil {
+			t.Errorf("newUnregisteredOwnerCodes() error = nil, want error for minimum edits %d", minEdits)
+		}
+	}
+}
+
+// levenshtein returns the count of substitutions, insertions and deletions
+// between a and b.
+func levenshtein(a, b string) int {
+	dist := make([]int, len(b)+1)
+	for j := range dist {
+		dist[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		prev := append([]int{}, dist...)
+		dist[0] = i
+		for j := 1; j <= len(b); j++ {
+			dist[j] = prev[j-1]
+			if a[i-1] != b[j-1] {
+				dist[j]++
+			}
+			if prev[j]+1 < dist[j] {
+				dist[j] = prev[j] + 1
+			}
+			if dist[j-1]+1 < dist[j] {
+				dist[j] = dist[j-1] + 1
+			}
+		}
+	}
+	return dist[len(b)]
+}
@@ -107,9 +107,13 @@ func (p *patternValue) newPatterns(value string) func(decoders decoders) [][]fun
 var pValue = newPatternValue()
 
 const (
-	outputAuto  = "auto"
-	outputFancy = "fancy"
-	outputCSV   = "csv"
+	outputAuto   = "auto"
+	outputFancy  = "fancy"
+	outputCSV    = "csv"
+	outputJSON   = "json"
+	outputNDJSON = "ndjson"
+	outputTable  = "table"
+	outputHTML   = "html"
 )
 
 type outputValue struct {
@@ -121,9 +125,13 @@ func newOutputValue() *outputValue {
 	return &outputValue{
 		value: configs.OutputDefVal,
 		printers: map[string]newPrinter{
-			outputAuto:  newAutoPrinter,
-			outputFancy: newFancyPrinter,
-			outputCSV:   newCSVPrinter,
+			outputAuto:   newAutoPrinter,
+			outputFancy:  newFancyPrinter,
+			outputCSV:    newCSVPrinter,
+			outputJSON:   newJSONPrinter,
+			outputNDJSON: newNDJSONPrinter,
+			outputTable:  newTablePrinter,
+			outputHTML:   newHTMLPrinter,
 		},
 	}
 }
@@ -146,10 +154,14 @@ func (o *outputValue) Type() string {
 	return "string"
 }
 
-const outputModesInfo string = ` ` + outputAuto + ` = for a single line '` + outputFancy +
+const outputModesInfo string = `  ` + outputAuto + ` = for a single line '` + outputFancy +
 	`' and for multiple lines '` + outputCSV + `' output 
-  ` + outputCSV + ` = machine readable CSV output
-` + outputFancy + ` = human readable fancy output`
+   ` + outputCSV + ` = machine readable CSV output
+ ` + outputFancy + ` = human readable fancy output
+  ` + outputJSON + ` = JSON array of objects
+` + outputNDJSON + ` = JSON object per line
+ ` + outputTable + ` = aligned table with a row per line
+  ` + outputHTML + ` = self-contained HTML report`
 
 func (o *outputValue) newPrinter(value string) newPrinter {
 	return o.printers[value]
@@ -157,12 +169,37 @@ func (o *outputValue) newPrinter(value string) newPrinter {
 
 var oValue = newOutputValue()
 
-func newValidateCmd(stdin io.Reader, writer io.Writer, viperCfg *viper.Viper, decoders decoders) *cobra.Command {
+const summary = "summary"
+
+func newValidateCmd(stdin io.Reader, writer, writerErr io.Writer, viperCfg *viper.Viper, decoders decoders) *cobra.Command {
 	validateCmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate intermodal container markings",
 		Long: `Validate intermodal container markings.
 
+The json and ndjson outputs print an object for every line with the input,
+the overall validity and for every part the value, the error message, the
+infos and the fields of the CSV output. The objects are described by the
+JSON Schema docs/validate-output.schema.json of the repository.
+
+The table output prints a row for every line with the validated marking,
+the validity, the owner company, the size and type and the first error.
+The table is printed after the last line and truncated to the width of
+the terminal. The environment variable COLUMNS overrides the width.
+
+The html output prints a self-contained HTML report with the counts of
+valid and invalid lines, the errors per part and a sortable table of all
+lines with the owner, the equipment category and the size and type.
+
+The --explain flag only extends the fancy output. With --explain=json the
+explanation replaces the output, so --output cannot be set. A container
+number without check digit is explained as well to derive its check digit.
+
+The summary prints the counts of all, valid and invalid lines, the counts
+of unknown owners, bad check digits, bad length codes, bad type codes and
+possible transposition errors, the top owners and the countries of the
+owners to stderr after the last line.
+
 ` + sepHelp,
 		Example: `  icm validate ABC
   icm validate ABC --pattern container-number
@@ -170,9 +207,18 @@ func newValidateCmd(stdin io.Reader, writer io.Writer, viperCfg *viper.Viper, de
   icm validate --sep-owner-equip '' --sep-serial-check '-' ABC U 123456 0
   icm validate ABC U 123456 0 20G1
   icm validate 20G1
+  icm validate --ocr A8C U 1234S6 O
+  icm validate --explain CSQ U 305438 3
+  icm validate --explain=json CSQ U 305438 3
+  icm validate --explain CSQ U 305438
+  icm generate --count 10 | icm validate --explain --output fancy
   icm generate | icm validate
   icm generate --count 10 | icm validate
-  icm generate --count 10 | icm validate --output fancy`,
+  icm generate --count 10 | icm validate --output fancy
+  icm generate --count 10 | icm validate --output ndjson | jq .valid
+  icm generate --count 10 --size-type | icm validate --output table
+  icm generate --count 10 --size-type | icm validate --output html > report.html
+  icm generate --count 10 | icm validate --summary > /dev/null`,
 		Args: cobra.MaximumNArgs(6),
 		// https://github.com/spf13/viper/issues/233
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -191,11 +237,34 @@ func newValidateCmd(stdin io.Reader, writer io.Writer, viperCfg *viper.Viper, de
 			peek, _ := bufReader.Peek(bufReader.Size())
 			isSingleLine := isSingleLine(string(peek))
 
-			printer := oValue.newPrinter(viperCfg.GetString(configs.Output))(writer, viperCfg, isSingleLine)
+			output := viperCfg.GetString(configs.Output)
+			if err := checkExplainOutput(viperCfg.GetString(explain), output, isSingleLine); err != nil {
+				return err
+			}
+
+			printer := oValue.newPrinter(output)(writer, viperCfg, isSingleLine)
+
+			switch viperCfg.GetString(explain) {
+			case explainFancy:
+				printer = &explainPrinter{printer: printer, writer: writer, indent: "  "}
+			case explainJSON:
+				printer = newExplainJSONPrinter(writer)
+			}
+
+			if viperCfg.GetBool(summary) {
+				printer = input.NewSummaryPrinter(printer, writerErr)
+			}
 
 			newPatterns := pValue.newPatterns(viperCfg.GetString(configs.Pattern))(decoders)
 
-			newInputs := input.Match(strings.Split(string(peek), "\n")[0], newPatterns)
+			isOCR := viperCfg.GetBool(configs.OCR)
+
+			firstLine := strings.Split(string(peek), "\n")[0]
+			if isOCR {
+				firstLine, _ = correctOCR(firstLine, decoders, viperCfg)
+			}
+
+			newInputs := input.Match(firstLine, newPatterns)
 
 			scanner := bufio.NewScanner(bufReader)
 
@@ -203,12 +272,25 @@ func newValidateCmd(stdin io.Reader, writer io.Writer, viperCfg *viper.Viper, de
 			var inputs []input.Input
 
 			for scanner.Scan() {
-				inputs, inputErr = input.Validate(scanner.Text(), newInputs)
+				line := scanner.Text()
+				var correction ocrCorrection
+				if isOCR {
+					line, correction = correctOCR(line, decoders, viperCfg)
+				}
+				inputs, inputErr = input.Validate(line, newInputs)
+				if isOCR {
+					appendOCRInfos(inputs, correction)
+				}
 				err := printer.Print(inputs)
 				if err != nil {
 					return err
 				}
 			}
+			if closer, ok := printer.(io.Closer); ok {
+				if err := closer.Close(); err != nil {
+					return err
+				}
+			}
 			return inputErr
 		},
 	}
@@ -228,9 +310,35 @@ func newValidateCmd(stdin io.Reader, writer io.Writer, viperCfg *viper.Viper, de
 		"ABCU1234560   20(*)G1  (*) separates size and type")
 	validateCmd.Flags().Bool(configs.NoHeader, configs.NoHeaderDefVal,
 		"omits header of CSV output")
+	validateCmd.Flags().Bool(configs.OCR, configs.OCRDefVal,
+		"corrects characters of container numbers that are confused by OCR")
+	validateCmd.Flags().Var(&explainValue{}, explain,
+		fmt.Sprintf("explains check digit calculation with\n%s\n", explainModesInfo))
+	validateCmd.Flags().Lookup(explain).NoOptDefVal = explainFancy
+	validateCmd.Flags().Bool(summary, false,
+		"prints a summary of all lines to stderr")
 	return validateCmd
 }
 
+// checkExplainOutput returns an error if the explanation would be mixed into
+// machine readable output or would replace an explicitly set output.
+func checkExplainOutput(explainMode, output string, isSingleLine bool) error {
+	switch explainMode {
+	case explainFancy:
+		if output == outputAuto && !isSingleLine {
+			return fmt.Errorf("--%s=%s cannot be used with %s output of multiple lines", explain, explainFancy, outputAuto)
+		}
+		if output != outputAuto && output != outputFancy {
+			return fmt.Errorf("--%s=%s cannot be used with %s output", explain, explainFancy, output)
+		}
+	case explainJSON:
+		if output != outputAuto {
+			return fmt.Errorf("--%s=%s cannot be used with %s output", explain, explainJSON, output)
+		}
+	}
+	return nil
+}
+
 func isSingleLine(s string) bool {
 	scanner := bufio.NewScanner(strings.NewReader(s))
 	counter := 0
@@ -243,6 +351,86 @@ func isSingleLine(s string) bool {
 	return true
 }
 
+// ocrCorrection holds the characters of a container number read by OCR and
+// the corrected container numbers ordered from most to least likely.
+type ocrCorrection struct {
+	in       string
+	chars    string
+	contNums []cont.Number
+}
+
+// correctOCR replaces the container number of a line with the most likely correction.
+func correctOCR(line string, decoders decoders, viperCfg *viper.Viper) (string, ocrCorrection) {
+	correction := ocrCorrection{in: strings.TrimSpace(line)}
+
+	chars := strings.Builder{}
+	rest := ""
+	for idx, r := range line {
+		if chars.Len() == 11 {
+			rest = line[idx:]
+			break
+		}
+		if r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			chars.WriteRune(r)
+		}
+	}
+	correction.chars = strings.ToUpper(chars.String())
+
+	correction.contNums = cont.CorrectOCR(correction.chars,
+		func(code string) bool {
+			found, _ := decoders.ownerDecodeUpdater.Decode(code)
+			return found
+		},
+		func(ID string) bool {
+			found, _ := decoders.equipCatDecoder.Decode(ID)
+			return found
+		})
+	if len(correction.contNums) == 0 {
+		return line, correction
+	}
+
+	contNum := correction.contNums[0]
+	contNum.SetSeparators(
+		viperCfg.GetString(configs.SepOE),
+		viperCfg.GetString(configs.SepES),
+		viperCfg.GetString(configs.SepSC),
+	)
+	return contNum.String() + rest, correction
+}
+
+// appendOCRInfos appends the OCR correction to the check digit or the last input.
+func appendOCRInfos(inputs []input.Input, correction ocrCorrection) {
+	idx := len(inputs) - 1
+	for i := range inputs {
+		if inputs[i].HasDatum(checkDigitHeader) {
+			idx = i
+			break
+		}
+	}
+
+	ocrInput := input.NewDatum("ocr-input").WithValue(correction.in)
+	ocrCorrected := input.NewDatum("ocr-correction")
+	ocrCandidates := input.NewDatum("ocr-candidates")
+
+	if len(correction.contNums) == 0 {
+		inputs[idx].AppendInfos(input.Info{Text: fmt.Sprintf("%s found", bold("no OCR correction"))})
+		inputs[idx].AppendData(ocrInput, ocrCorrected, ocrCandidates)
+		return
+	}
+
+	best := correction.contNums[0]
+	best.SetSeparators("", "", "")
+	if best.String() == correction.chars {
+		inputs[idx].AppendData(ocrInput, ocrCorrected, ocrCandidates)
+		return
+	}
+
+	infos := []input.Info{{Text: fmt.Sprintf("%s of %s", bold("OCR correction"), underline(correction.in))}}
+	infos, candidates := appendContNumsInfo("Less likely OCR corrections:", correction.contNums[1:], infos)
+	inputs[idx].AppendInfos(infos...)
+	inputs[idx].AppendData(ocrInput, ocrCorrected.WithValue(best.String()), ocrCandidates.WithValue(candidates))
+}
+
 func newAutoPrinter(writer io.Writer, viperCfg *viper.Viper, isSingleLine bool) input.Printer {
 	if isSingleLine {
 		return newFancyPrinter(writer, viperCfg, isSingleLine)
@@ -255,37 +443,65 @@ func newFancyPrinter(writer io.Writer, viperCfg *viper.Viper, isSingleLine bool)
 	fancyPrinter := input.NewFancyPrinter(writer)
 	fancyPrinter.SetIndent("  ")
 	fancyPrinter.SetSeparatorsFunc(func(inputs []input.Input) {
-		// only size-type has 3 inputs
-		if len(inputs) == 3 {
-			fancyPrinter.SetSeparators(
-				"",
-				viperCfg.GetString(configs.SepST),
-			)
-		} else {
-			fancyPrinter.SetSeparators(
-				viperCfg.GetString(configs.SepOE),
-				viperCfg.GetString(configs.SepES),
-				viperCfg.GetString(configs.SepSC),
-				viperCfg.GetString(configs.SepCS),
-				"",
-				viperCfg.GetString(configs.SepST),
-			)
-		}
+		fancyPrinter.SetSeparators(inputSeparators(viperCfg, inputs)...)
 	})
 	return fancyPrinter
 }
 
+func newTablePrinter(writer io.Writer, viperCfg *viper.Viper, isSingleLine bool) input.Printer {
+	tablePrinter := input.NewTablePrinter(writer, terminalWidth())
+	tablePrinter.SetSeparatorsFunc(func(inputs []input.Input) {
+		tablePrinter.SetSeparators(inputSeparators(viperCfg, inputs)...)
+	})
+	return tablePrinter
+}
+
+func newHTMLPrinter(writer io.Writer, viperCfg *viper.Viper, isSingleLine bool) input.Printer {
+	htmlPrinter := input.NewHTMLPrinter(writer)
+	htmlPrinter.SetSeparatorsFunc(func(inputs []input.Input) {
+		htmlPrinter.SetSeparators(inputSeparators(viperCfg, inputs)...)
+	})
+	return htmlPrinter
+}
+
+// inputSeparators returns the configured separators between inputs.
+func inputSeparators(viperCfg *viper.Viper, inputs []input.Input) []string {
+	// Only the size-type pattern starts with the length code.
+	if len(inputs) > 0 && inputs[0].HasDatum(lengthCodeHeader) {
+		return []string{
+			"",
+			viperCfg.GetString(configs.SepST),
+		}
+	}
+	return []string{
+		viperCfg.GetString(configs.SepOE),
+		viperCfg.GetString(configs.SepES),
+		viperCfg.GetString(configs.SepSC),
+		viperCfg.GetString(configs.SepCS),
+		"",
+		viperCfg.GetString(configs.SepST),
+	}
+}
+
 func newCSVPrinter(writer io.Writer, viperCfg *viper.Viper, isSingleLine bool) input.Printer {
 	csvWriter := csv.NewWriter(writer)
 	csvWriter.Comma = ';'
 	return input.NewCSVPrinter(csvWriter, viperCfg.GetBool(configs.NoHeader))
 }
 
+func newJSONPrinter(writer io.Writer, viperCfg *viper.Viper, isSingleLine bool) input.Printer {
+	return input.NewJSONPrinter(writer)
+}
+
+func newNDJSONPrinter(writer io.Writer, viperCfg *viper.Viper, isSingleLine bool) input.Printer {
+	return input.NewNDJSONPrinter(writer)
+}
+
 func newAutoPattern(decoders decoders) [][]func() input.Input {
 	owner := newOwnerInput(decoders.ownerDecodeUpdater)
 	equipCat := newEquipCatInput(decoders.equipCatDecoder)
 	serialNum := newSerialNumInput()
-	checkDigit := newCheckDigitInput()
+	checkDigit := newCheckDigitInput(decoders.ownerDecodeUpdater, decoders.equipCatDecoder)
 	length := newLengthInput(decoders.lengthDecoder)
 	heightWidth := newHeightWidthInput(decoders.heightWidthDecoder)
 	typeAndGroup := newTypeAndGroupInput(decoders.typeDecoder)
@@ -293,6 +509,7 @@ func newAutoPattern(decoders decoders) [][]func() input.Input {
 	return [][]func() input.Input{
 		{owner, equipCat, serialNum, checkDigit, length, heightWidth, typeAndGroup},
 		{owner, equipCat, serialNum, checkDigit},
+		{owner, equipCat, serialNum},
 		{owner, equipCat},
 		{owner},
 		{length, heightWidth, typeAndGroup},
@@ -303,7 +520,7 @@ func newContNumPattern(decoders decoders) [][]func() input.Input {
 	owner := newOwnerInput(decoders.ownerDecodeUpdater)
 	equipCat := newEquipCatInput(decoders.equipCatDecoder)
 	serialNum := newSerialNumInput()
-	checkDigit := newCheckDigitInput()
+	checkDigit := newCheckDigitInput(decoders.ownerDecodeUpdater, decoders.equipCatDecoder)
 
 	return [][]func() input.Input{{owner, equipCat, serialNum, checkDigit}}
 }
@@ -440,16 +657,20 @@ func newSerialNumInput() func() input.Input {
 	}
 }
 
-func newCheckDigitInput() func() input.Input {
+// checkDigitHeader is the header of the datum of the check digit input.
+const checkDigitHeader = "check-digit"
+
+func newCheckDigitInput(ownerDecoder data.OwnerDecoder, equipCatDecoder data.EquipCatDecoder) func() input.Input {
 	return func() input.Input {
 		return input.NewInput(
 			1,
 			regexp.MustCompile(`\d`).FindStringIndex,
 			func(value string, previousValues []string) (error, []input.Info, []input.Datum) {
-				checkDigitDatum := input.NewDatum("check-digit").WithValue(value)
+				checkDigitDatum := input.NewDatum(checkDigitHeader).WithValue(value)
 				calcCheckDigitDatum := input.NewDatum("calculated-check-digit")
 				validCheckDigit := input.NewDatum("valid-check-digit")
 				possibleTranspositionError := input.NewDatum("possible-transposition-error")
+				possibleSubstitutionError := input.NewDatum("possible-substitution-error")
 				if len(strings.Join(previousValues[0:3], "")) != 10 {
 					return newErrValidate(fmt.Sprintf("%s is not calculable",
 							underline("check digit"))),
@@ -459,6 +680,7 @@ func newCheckDigitInput() func() input.Input {
 							calcCheckDigitDatum,
 							validCheckDigit.WithValue(fmt.Sprintf("%t", false)),
 							possibleTranspositionError,
+							possibleSubstitutionError,
 						}
 				}
 
@@ -478,10 +700,22 @@ func newCheckDigitInput() func() input.Input {
 							calcCheckDigitDatum.WithValue(strconv.Itoa(checkDigit)),
 							validCheckDigit.WithValue(fmt.Sprintf("%t", false)),
 							possibleTranspositionError,
+							possibleSubstitutionError,
 						}
 				}
 
 				if number != checkDigit%10 {
+					substitutedContNums := cont.CheckSubstitution(previousValues[2], previousValues[1], previousValues[0],
+						number,
+						func(code string) bool {
+							found, _ := ownerDecoder.Decode(code)
+							return found
+						},
+						func(ID string) bool {
+							found, _ := equipCatDecoder.Decode(ID)
+							return found
+						})
+					infos, substitutionErrors := appendContNumsInfo("Possible substitution errors:", substitutedContNums, infos)
 					return newErrValidate(fmt.Sprintf(
 							"calculated %s is %s",
 							underline("check digit"),
@@ -492,28 +726,22 @@ func newCheckDigitInput() func() input.Input {
 							calcCheckDigitDatum.WithValue(strconv.Itoa(checkDigit)),
 							validCheckDigit.WithValue(fmt.Sprintf("%t", number == checkDigit%10)),
 							possibleTranspositionError,
+							possibleSubstitutionError.WithValue(substitutionErrors),
 						}
 				}
 
 				transposedContNums := cont.CheckTransposition(previousValues[2], previousValues[1], previousValues[0])
 
 				if len(transposedContNums) != 0 {
-					infos = append(infos, input.Info{Text: "Possible transposition errors:"})
-					builder := strings.Builder{}
-					for idx, contNum := range transposedContNums {
-						infos = append(infos, input.Info{Text: fmt.Sprintf("  %s", contNum)})
-						builder.WriteString(contNum.String())
-						if idx < len(transposedContNums)-1 {
-							builder.WriteString(", ")
-						}
-					}
+					infos, transpositionErrors := appendContNumsInfo("Possible transposition errors:", transposedContNums, infos)
 					return nil,
 						infos,
 						[]input.Datum{
 							checkDigitDatum,
 							calcCheckDigitDatum.WithValue(strconv.Itoa(checkDigit)),
 							validCheckDigit.WithValue(fmt.Sprintf("%t", number == checkDigit%10)),
-							possibleTranspositionError.WithValue(builder.String()),
+							possibleTranspositionError.WithValue(transpositionErrors),
+							possibleSubstitutionError,
 						}
 				}
 
@@ -524,11 +752,30 @@ func newCheckDigitInput() func() input.Input {
 						calcCheckDigitDatum.WithValue(strconv.Itoa(checkDigit)),
 						validCheckDigit.WithValue(fmt.Sprintf("%t", number == checkDigit%10)),
 						possibleTranspositionError,
+						possibleSubstitutionError,
 					}
 			})
 	}
 }
 
+// appendContNumsInfo appends a title and container numbers to infos and
+// returns container numbers as comma separated list.
+func appendContNumsInfo(title string, contNums []cont.Number, infos []input.Info) ([]input.Info, string) {
+	if len(contNums) == 0 {
+		return infos, ""
+	}
+	infos = append(infos, input.Info{Text: title})
+	builder := strings.Builder{}
+	for idx, contNum := range contNums {
+		infos = append(infos, input.Info{Text: fmt.Sprintf("  %s", contNum)})
+		builder.WriteString(contNum.String())
+		if idx < len(contNums)-1 {
+			builder.WriteString(", ")
+		}
+	}
+	return infos, builder.String()
+}
+
 func appendCheckDigit10Info(checkDigit int, infos []input.Info) []input.Info {
 	if checkDigit == 10 {
 		if infos == nil {
@@ -542,13 +789,16 @@ func appendCheckDigit10Info(checkDigit int, infos []input.Info) []input.Info {
 	return infos
 }
 
+// lengthCodeHeader is the header of the datum of the length code input.
+const lengthCodeHeader = "length-code"
+
 func newLengthInput(lengthDecoder data.LengthDecoder) func() input.Input {
 
 	length := input.NewInput(
 		1,
 		regexp.MustCompile(`[A-Za-z\d]`).FindStringIndex,
 		func(value string, previousValues []string) (error, []input.Info, []input.Datum) {
-			lengthDatum := input.NewDatum("length-code").WithValue(value)
+			lengthDatum := input.NewDatum(lengthCodeHeader).WithValue(value)
 			lengthDescDatum := input.NewDatum("length-description")
 			if value == "" {
 				return newErrValidate(fmt.Sprintf("%s is not a %s or a %s",
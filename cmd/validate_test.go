@@ -15,9 +15,12 @@ package cmd
 
 import (
 	"bytes"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/meyermarcel/icm/configs"
+	"github.com/meyermarcel/icm/internal/input"
 	"github.com/spf13/viper"
 )
 
@@ -98,6 +101,134 @@ func Test_validateCmd(t *testing.T) {
 
 `,
 		},
+		{
+			"Validate container number with wrong check digit",
+			[]string{" abc u 123456 1 "},
+			nil,
+			true,
+			`
+  ABC U 123456 1  ✘
+   ↑  ↑        ↑
+   │  │        └─ calculated check digit is 0
+   │  │           Possible substitution errors:
+   │  │             ABC G 123456 1
+   │  │             ABC Q 123456 1
+   │  │             ABC U 113456 1
+   │  │             ABC U 128456 1
+   │  │             ABC U 123156 1
+   │  │             ABC U 123496 1
+   │  │             ABC U 123458 1
+   │  │             ABC U 123456 0
+   │  │
+   │  └─ some-equip-cat-ID
+   │
+   └─ some-company
+      some-city
+      some-country
+
+`,
+		},
+		{
+			"Validate container number with OCR correction",
+			[]string{" a8c u 1234s6 o "},
+			[]cfgOverride{{configs.OCR, "true"}},
+			false,
+			`
+  ABC U 123456 0  ✔
+   ↑  ↑        ↑
+   │  │        └─ OCR correction of a8c u 1234s6 o
+   │  │
+   │  └─ some-equip-cat-ID
+   │
+   └─ some-company
+      some-city
+      some-country
+
+`,
+		},
+		{
+			"Validate container number with explanation",
+			[]string{" abc u 123416 0 "},
+			[]cfgOverride{{explain, explainFancy}},
+			false,
+			`
+  ABC U 123416 0  ✔
+   ↑  ↑        ↑
+   │  │        └─ It is not recommended to use a serial number
+   │  │           that generates check digit 10 (0).
+   │  │           Possible transposition errors:
+   │  │             ABC U 132416 0
+   │  │
+   │  └─ some-equip-cat-ID
+   │
+   └─ some-company
+      some-city
+      some-country
+
+
+  Check digit calculation of ABCU123416
+
+  character  A  B  C   U  1  2   3   4   1    6
+  value     10 12 13  32  1  2   3   4   1    6
+  weight     1  2  4   8 16 32  64 128 256  512
+  product   10 24 52 256 16 64 192 512 256 3072
+
+  sum of products  4454
+  4454 mod 11      10
+  check digit      10 → 0
+
+`,
+		},
+		{
+			"Validate container number without check digit with explanation",
+			[]string{"abc u 123456"},
+			[]cfgOverride{{explain, explainFancy}},
+			false,
+			`
+  ABC U 123456  ✔
+   ↑  ↑
+   │  └─ some-equip-cat-ID
+   │
+   └─ some-company
+      some-city
+      some-country
+
+
+  Check digit calculation of ABCU123456
+
+  character  A  B  C   U  1  2   3   4    5    6
+  value     10 12 13  32  1  2   3   4    5    6
+  weight     1  2  4   8 16 32  64 128  256  512
+  product   10 24 52 256 16 64 192 512 1280 3072
+
+  sum of products  5478
+  5478 mod 11      0
+  check digit      0
+
+`,
+		},
+		{
+			"Validate container number with JSON explanation",
+			[]string{" abc u 123456 0 "},
+			[]cfgOverride{{explain, explainJSON}},
+			false,
+			`{"input":"ABCU123456","calculable":true,"steps":[{"character":"A","value":10,"weight":1,"product":10},{"character":"B","value":12,"weight":2,"product":24},{"character":"C","value":13,"weight":4,"product":52},{"character":"U","value":32,"weight":8,"product":256},{"character":"1","value":1,"weight":16,"product":16},{"character":"2","value":2,"weight":32,"product":64},{"character":"3","value":3,"weight":64,"product":192},{"character":"4","value":4,"weight":128,"product":512},{"character":"5","value":5,"weight":256,"product":1280},{"character":"6","value":6,"weight":512,"product":3072}],"sum":5478,"remainder":0,"check-digit":0}
+`,
+		},
+		{
+			"Validate with explanation returns error for CSV output",
+			[]string{"ABC U 123456 0"},
+			[]cfgOverride{{explain, explainFancy}, {configs.Output, outputCSV}},
+			true,
+			"",
+		},
+		{
+			"Validate with JSON explanation returns error for set output",
+			[]string{"ABC U 123456 0"},
+			[]cfgOverride{{explain, explainJSON}, {configs.Output, outputNDJSON}},
+			true,
+			"",
+		},
 		{
 			"Validate owner, equipment category ID, serial number and check digit",
 			[]string{" abc u 123456 0 "},
@@ -277,6 +408,24 @@ func Test_validateCmd(t *testing.T) {
   │
   └─ length: some-length
 
+`,
+		},
+		{
+			"Validate container number with JSON output",
+			[]string{"ABC U 123456 0"},
+			[]cfgOverride{{configs.Output, outputJSON}},
+			false,
+			`[
+  {"input":"ABC U 123456 0","valid":true,"parts":[{"name":"owner-code","value":"ABC","valid":true,"infos":["some-company","some-city","some-country"],"data":{"city":"some-city","company":"some-company","country":"some-country","owner-code":"ABC"}},{"name":"equipment-category-id","value":"U","valid":true,"infos":["some-equip-cat-ID"],"data":{"equipment-category":"some-equip-cat-ID","equipment-category-id":"U"}},{"name":"serial-number","value":"123456","valid":true,"infos":[],"data":{"serial-number":"123456"}},{"name":"check-digit","value":"0","valid":true,"infos":[],"data":{"calculated-check-digit":"0","check-digit":"0","possible-substitution-error":"","possible-transposition-error":"","valid-check-digit":"true"}}]}
+]
+`,
+		},
+		{
+			"Validate invalid container number with NDJSON output",
+			[]string{"ABC U 123456 1"},
+			[]cfgOverride{{configs.Output, outputNDJSON}},
+			true,
+			`{"input":"ABC U 123456 1","valid":false,"parts":[{"name":"owner-code","value":"ABC","valid":true,"infos":["some-company","some-city","some-country"],"data":{"city":"some-city","company":"some-company","country":"some-country","owner-code":"ABC"}},{"name":"equipment-category-id","value":"U","valid":true,"infos":["some-equip-cat-ID"],"data":{"equipment-category":"some-equip-cat-ID","equipment-category-id":"U"}},{"name":"serial-number","value":"123456","valid":true,"infos":[],"data":{"serial-number":"123456"}},{"name":"check-digit","value":"1","valid":false,"error":"calculated check digit is 0","infos":["Possible substitution errors:","  ABC G 123456 1","  ABC Q 123456 1","  ABC U 113456 1","  ABC U 128456 1","  ABC U 123156 1","  ABC U 123496 1","  ABC U 123458 1","  ABC U 123456 0"],"data":{"calculated-check-digit":"0","check-digit":"1","possible-substitution-error":"ABC G 123456 1, ABC Q 123456 1, ABC U 113456 1, ABC U 128456 1, ABC U 123156 1, ABC U 123496 1, ABC U 123458 1, ABC U 123456 0","possible-transposition-error":"","valid-check-digit":"false"}}]}
 `,
 		},
 	}
@@ -296,7 +445,7 @@ func Test_validateCmd(t *testing.T) {
 			for _, override := range tt.cfgOverrides {
 				viperCfg.Set(override.name, override.value)
 			}
-			cmd := newValidateCmd(nil, writer, viperCfg, d)
+			cmd := newValidateCmd(nil, writer, &bytes.Buffer{}, viperCfg, d)
 			_ = cmd.PreRunE(cmd, nil)
 			if got := cmd.RunE(nil, tt.args); (got == nil) == tt.wantErr {
 				t.Errorf("got = %v, wantErr is %v", got, tt.wantErr)
@@ -307,3 +456,124 @@ func Test_validateCmd(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateCmd_table(t *testing.T) {
+	columns, ok := os.LookupEnv("COLUMNS")
+	_ = os.Setenv("COLUMNS", "0")
+	defer func() {
+		if ok {
+			_ = os.Setenv("COLUMNS", columns)
+		} else {
+			_ = os.Unsetenv("COLUMNS")
+		}
+	}()
+
+	writer := &bytes.Buffer{}
+	viperCfg := viper.New()
+	viperCfg.Set(configs.Output, outputTable)
+	cmd := newValidateCmd(strings.NewReader("ABC U 123456 0 20G1\nDEF U 123456 0\n"), writer, &bytes.Buffer{},
+		viperCfg, newDummyDecoders())
+	_ = cmd.PreRunE(cmd, nil)
+	if err := cmd.RunE(cmd, nil); err == nil {
+		t.Errorf("got = nil, want error")
+	}
+	want := `number                     company       size and type                                    error
+ABC U 123456 0   20 G1  ✔  some-company  some-length, some-height, some-width, some-type
+DEF U 123456 0   __ __  ✘                                                                 DEF is not registered (e.g. RAN)
+`
+	if gotWriter := writer.String(); gotWriter != want {
+		t.Errorf("gotWriter = %v, want %v", gotWriter, want)
+	}
+}
+
+func Test_validateCmd_html(t *testing.T) {
+	writer := &bytes.Buffer{}
+	viperCfg := viper.New()
+	viperCfg.Set(configs.Output, outputHTML)
+	cmd := newValidateCmd(strings.NewReader("ABC U 123456 0 20G1\nDEF U 123456 0\n"), writer, &bytes.Buffer{},
+		viperCfg, newDummyDecoders())
+	_ = cmd.PreRunE(cmd, nil)
+	if err := cmd.RunE(cmd, nil); err == nil {
+		t.Errorf("got = nil, want error")
+	}
+	gotWriter := writer.String()
+	for _, want := range []string{
+		`<tr><td>Lines</td><td>2</td></tr>`,
+		`<tr><td class="valid">Valid</td><td>1</td></tr>`,
+		`<tr><td class="invalid">Invalid</td><td>1</td></tr>`,
+		`<tr><td>Errors of owner-code</td><td>1</td></tr>`,
+		`<td class="marking">ABC U 123456 0 20G1</td>`,
+		`<span class="invalid" title="DEF is not registered (e.g. RAN)">DEF</span> `,
+		`<td>some-company, some-city, some-country</td>`,
+		`<td>some-length, some-height, some-width, some-type</td>`,
+	} {
+		if !strings.Contains(gotWriter, want) {
+			t.Errorf("gotWriter = %v, want contains %v", gotWriter, want)
+		}
+	}
+}
+
+func Test_validateCmd_summary(t *testing.T) {
+	writer := &bytes.Buffer{}
+	writerErr := &bytes.Buffer{}
+	viperCfg := viper.New()
+	viperCfg.Set(configs.Output, outputNDJSON)
+	viperCfg.Set(summary, true)
+	cmd := newValidateCmd(strings.NewReader(
+		"ABC U 123456 0 20G1\nABC U 123456 1 20G1\nDEF U 123456 0 20G1\nABC U 100031 0 --\n"),
+		writer, writerErr, viperCfg, newDummyDecoders())
+	_ = cmd.PreRunE(cmd, nil)
+	if err := cmd.RunE(cmd, nil); err == nil {
+		t.Errorf("got = nil, want error")
+	}
+	if gotLines := strings.Count(writer.String(), "\n"); gotLines != 4 {
+		t.Errorf("got %d lines of output, want 4", gotLines)
+	}
+	want := `
+Summary
+  lines                   4
+  valid                   1
+  invalid                 3
+  unknown owner           1
+  bad check digit         1
+  bad length code         1
+  bad type code           1
+  possible transposition  1
+
+Top owners
+  ABC  some-company  3
+
+Countries of owners
+  some-country  3
+`
+	if gotWriterErr := writerErr.String(); gotWriterErr != want {
+		t.Errorf("gotWriterErr = %v, want %v", gotWriterErr, want)
+	}
+}
+
+func Test_appendOCRInfos(t *testing.T) {
+	tests := []struct {
+		name          string
+		checkDigitIdx int
+		len           int
+		wantIdx       int
+	}{
+		{"Append to check digit", 1, 3, 1},
+		{"Append to check digit after size and type", 4, 5, 4},
+		{"Append to last input without check digit", -1, 3, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs := make([]input.Input, tt.len)
+			if tt.checkDigitIdx > -1 {
+				inputs[tt.checkDigitIdx].AppendData(input.NewDatum(checkDigitHeader))
+			}
+			appendOCRInfos(inputs, ocrCorrection{in: "some-line"})
+			for idx := range inputs {
+				if got := inputs[idx].HasDatum("ocr-input"); got != (idx == tt.wantIdx) {
+					t.Errorf("input %d has OCR datum = %v, want %v", idx, got, idx == tt.wantIdx)
+				}
+			}
+		})
+	}
+}
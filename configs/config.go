@@ -23,6 +23,8 @@ const (
 	PatternDefVal  = "auto"
 	NoHeader       = "no-header"
 	NoHeaderDefVal = false
+	OCR            = "ocr"
+	OCRDefVal      = false
 	Output         = "output"
 	OutputDefVal   = "auto"
 	SepOE          = "sep-owner-equip"
@@ -56,6 +58,9 @@ func Cfg() []byte {
 # No header for CSV output
 ` + NoHeader + `: ` + fmt.Sprintf("%t", NoHeaderDefVal) + `
 
+# Correct characters of container numbers that are confused by OCR
+` + OCR + `: ` + fmt.Sprintf("%t", OCRDefVal) + `
+
 #  Separators
 #
 #  ABC U 123456 0   20 G1
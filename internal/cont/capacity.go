@@ -0,0 +1,83 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Capacity counts the serial numbers of a range by their usability. Free serial
+// numbers have no check digit 10, no possible transposition error and are not used.
+type Capacity struct {
+	Range            Range
+	CheckDigit10     int
+	TranspositionErr int
+	Used             int
+	Free             int
+}
+
+// AnalyzeCapacity returns the capacity of all serial numbers of the owner code and
+// equipment category ID and the capacities of consecutive ranges of rangeSize serial
+// numbers. Only used container numbers of the owner code and equipment category ID
+// are counted.
+func AnalyzeCapacity(ownerCode, equipCatID string, used []Number, rangeSize int) (Capacity, []Capacity, error) {
+	all, err := NewRange(ownerCode, equipCatID, 0, 999999)
+	if err != nil {
+		return Capacity{}, nil, err
+	}
+	if rangeSize < 1 || rangeSize > all.Len() {
+		return Capacity{}, nil, fmt.Errorf("range size %d is not in range from 1 to %d", rangeSize, all.Len())
+	}
+
+	isUsed := make(map[int]bool)
+	for _, contNum := range used {
+		if !all.Contains(contNum) {
+			continue
+		}
+		serialNum, _ := strconv.Atoi(contNum.SerialNumber())
+		isUsed[serialNum] = true
+	}
+
+	total := Capacity{Range: all}
+	var ranges []Capacity
+	for from := 0; from < all.Len(); from += rangeSize {
+		r, _ := NewRange(ownerCode, equipCatID, from, min(from+rangeSize, all.Len())-1)
+		ranges = append(ranges, Capacity{Range: r})
+	}
+	for num := 0; num < all.Len(); num++ {
+		serialNum := formatSerialNum(num)
+		checkDigit10 := CalcCheckDigit(ownerCode, equipCatID, serialNum) == 10
+		transpositionErr := len(CheckTransposition(ownerCode, equipCatID, serialNum)) > 0
+		for _, c := range []*Capacity{&total, &ranges[num/rangeSize]} {
+			c.count(checkDigit10, transpositionErr, isUsed[num])
+		}
+	}
+	return total, ranges, nil
+}
+
+func (c *Capacity) count(checkDigit10, transpositionErr, used bool) {
+	if checkDigit10 {
+		c.CheckDigit10++
+	}
+	if transpositionErr {
+		c.TranspositionErr++
+	}
+	if used {
+		c.Used++
+	}
+	if !checkDigit10 && !transpositionErr && !used {
+		c.Free++
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeCapacity(t *testing.T) {
+	used := []Number{
+		newNum("ABC", "U", "000000", 1),
+		newNum("ABC", "U", "000000", 1),
+		newNum("ABC", "J", "000002", 5),
+		newNum("DEF", "U", "000002", 2),
+	}
+	gotTotal, gotRanges, err := AnalyzeCapacity("ABC", "U", used, 250000)
+	if err != nil {
+		t.Fatalf("AnalyzeCapacity() error = %v", err)
+	}
+	wantTotal := Capacity{newTestRange(t, 0, 999999), 90909, 83124, 1, 865790}
+	if !reflect.DeepEqual(gotTotal, wantTotal) {
+		t.Errorf("AnalyzeCapacity() total = %v, want %v", gotTotal, wantTotal)
+	}
+	wantRanges := []Capacity{
+		{newTestRange(t, 0, 249999), 22726, 21078, 1, 216581},
+		{newTestRange(t, 250000, 499999), 22728, 20459, 0, 216583},
+		{newTestRange(t, 500000, 749999), 22728, 20441, 0, 216659},
+		{newTestRange(t, 750000, 999999), 22727, 21146, 0, 215967},
+	}
+	if !reflect.DeepEqual(gotRanges, wantRanges) {
+		t.Errorf("AnalyzeCapacity() ranges = %v, want %v", gotRanges, wantRanges)
+	}
+}
+
+func TestAnalyzeCapacity_lastRange(t *testing.T) {
+	_, ranges, err := AnalyzeCapacity("ABC", "U", nil, 300000)
+	if err != nil {
+		t.Fatalf("AnalyzeCapacity() error = %v", err)
+	}
+	if got, want := ranges[len(ranges)-1].Range, newTestRange(t, 900000, 999999); got != want {
+		t.Errorf("AnalyzeCapacity() last range = %v, want %v", got, want)
+	}
+}
+
+func TestAnalyzeCapacityErr(t *testing.T) {
+	tests := []struct {
+		name       string
+		ownerCode  string
+		equipCatID string
+		rangeSize  int
+	}{
+		{"Invalid owner code", "AB", "U", 100000},
+		{"Invalid equipment category ID", "ABC", "u", 100000},
+		{"Range size 0", "ABC", "U", 0},
+		{"Range size greater than serial numbers", "ABC", "U", 1000001},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := AnalyzeCapacity(tt.ownerCode, tt.equipCatID, nil, tt.rangeSize); err == nil {
+				t.Errorf("AnalyzeCapacity() error = nil, want error")
+			}
+		})
+	}
+}
@@ -17,16 +17,75 @@ import (
 	"strings"
 )
 
+// checkDigitChars has the characters at the index of their numeric value.
+// Multiples of 11 are skipped and marked with '?'.
+const checkDigitChars = "0123456789A?BCDEFGHIJK?LMNOPQRSTU?VWXYZ"
+
+// checkDigitValues has the numeric values of ASCII characters and -1 for
+// characters without value, like strings.IndexRune on checkDigitChars.
+var checkDigitValues = func() (values [128]int) {
+	for i := range values {
+		values[i] = -1
+	}
+	for i, character := range checkDigitChars {
+		if character != '?' {
+			values[character] = i
+		}
+	}
+	return
+}()
+
 // CalcCheckDigit calculates check digit for owner, equipment category ID and serial number.
 func CalcCheckDigit(ownerCode string, equipCatID string, serialNum string) int {
+	sum := 0
+	weight := 1
+	for _, part := range [...]string{ownerCode, equipCatID, serialNum} {
+		for _, character := range part {
+			value := -1
+			if character < 128 {
+				value = checkDigitValues[character]
+			}
+			sum += weight * value
+			weight *= 2
+		}
+	}
+	return sum % 11
+}
+
+// CheckDigitStep is the calculation step for one character.
+type CheckDigitStep struct {
+	Char    string
+	Value   int
+	Weight  int
+	Product int
+}
 
-	concat := ownerCode + equipCatID + serialNum
+// CheckDigitExplanation explains the calculation of a check digit.
+// Remainder is the sum modulo 11 and CheckDigit is the remainder modulo 10.
+type CheckDigitExplanation struct {
+	Steps      []CheckDigitStep
+	Sum        int
+	Remainder  int
+	CheckDigit int
+}
 
-	n := 0.0
-	d := 0.5
-	for _, character := range concat {
-		d *= 2
-		n += d * float64(strings.IndexRune("0123456789A?BCDEFGHIJK?LMNOPQRSTU?VWXYZ", character))
+// ExplainCheckDigit calculates check digit for owner, equipment category ID and serial number
+// step by step.
+func ExplainCheckDigit(ownerCode string, equipCatID string, serialNum string) CheckDigitExplanation {
+	explanation := CheckDigitExplanation{}
+	weight := 1
+	for _, character := range ownerCode + equipCatID + serialNum {
+		value := strings.IndexRune(checkDigitChars, character)
+		explanation.Steps = append(explanation.Steps, CheckDigitStep{
+			Char:    string(character),
+			Value:   value,
+			Weight:  weight,
+			Product: value * weight,
+		})
+		explanation.Sum += value * weight
+		weight *= 2
 	}
-	return int(n) - int(n/11)*11
+	explanation.Remainder = explanation.Sum % 11
+	explanation.CheckDigit = explanation.Remainder % 10
+	return explanation
 }
@@ -14,6 +14,7 @@
 package cont
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -55,3 +56,38 @@ func TestCalcCheckDigit(t *testing.T) {
 		})
 	}
 }
+
+func TestExplainCheckDigit(t *testing.T) {
+	got := ExplainCheckDigit("CSQ", "U", "305438")
+	want := CheckDigitExplanation{
+		Steps: []CheckDigitStep{
+			{"C", 13, 1, 13},
+			{"S", 30, 2, 60},
+			{"Q", 28, 4, 112},
+			{"U", 32, 8, 256},
+			{"3", 3, 16, 48},
+			{"0", 0, 32, 0},
+			{"5", 5, 64, 320},
+			{"4", 4, 128, 512},
+			{"3", 3, 256, 768},
+			{"8", 8, 512, 4096},
+		},
+		Sum:        6185,
+		Remainder:  3,
+		CheckDigit: 3,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExplainCheckDigit() = %v, want %v", got, want)
+	}
+
+	for _, tt := range []struct{ ownerCode, equipCatID, serialNum string }{
+		{"ABC", "U", "123456"},
+		{"NYK", "U", "000000"},
+		{"CMA", "U", "163912"},
+	} {
+		explanation := ExplainCheckDigit(tt.ownerCode, tt.equipCatID, tt.serialNum)
+		if calc := CalcCheckDigit(tt.ownerCode, tt.equipCatID, tt.serialNum); explanation.Remainder != calc {
+			t.Errorf("ExplainCheckDigit() remainder = %v, want %v", explanation.Remainder, calc)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Placeholder stands for an unknown character in a container number pattern.
+const Placeholder = '?'
+
+// maxCompletions limits the combinations of a container number pattern.
+const maxCompletions = 10000000
+
+// Complete returns all container numbers with a correct check digit that match
+// a pattern of 11 characters. The Placeholder stands for every character that is
+// valid at its position. Owner codes and equipment category IDs are only used if
+// isOwnerCode and isEquipCatID return true for them.
+func Complete(pattern string, isOwnerCode func(code string) bool, isEquipCatID func(ID string) bool) ([]Number, error) {
+	pattern = strings.ToUpper(pattern)
+	if len(pattern) != 11 {
+		return nil, NewErrContValidate(fmt.Sprintf("%s is not 11 characters long", pattern))
+	}
+
+	combinations := 1
+	for pos := range pattern {
+		char := pattern[pos]
+		switch {
+		case char == Placeholder && pos < 4:
+			combinations *= 26
+		case char == Placeholder && pos < 10:
+			combinations *= 10
+		case char == Placeholder:
+		case pos < 4 && !isUpperLetter(string(char)):
+			return nil, NewErrContValidate(fmt.Sprintf("%c at position %d is not a letter", char, pos+1))
+		case pos >= 4 && !isDigits(string(char)):
+			return nil, NewErrContValidate(fmt.Sprintf("%c at position %d is not a digit", char, pos+1))
+		}
+	}
+	if combinations > maxCompletions {
+		return nil, NewErrContValidate(
+			fmt.Sprintf("%s has %d combinations and exceeds limit of %d", pattern, combinations, maxCompletions))
+	}
+
+	contNums := make([]Number, 0)
+	var complete func(prefix string)
+	complete = func(prefix string) {
+		pos := len(prefix)
+		switch {
+		case pos == 3 && !isOwnerCode(prefix):
+			return
+		case pos == 4 && !isEquipCatID(prefix[3:]):
+			return
+		case pos == 10:
+			checkDigit := CalcCheckDigit(prefix[0:3], prefix[3:4], prefix[4:10]) % 10
+			if pattern[10] == Placeholder || int(pattern[10]-'0') == checkDigit {
+				contNums = append(contNums, newNum(prefix[0:3], prefix[3:4], prefix[4:10], checkDigit))
+			}
+			return
+		}
+		if pattern[pos] != Placeholder {
+			complete(prefix + pattern[pos:pos+1])
+			return
+		}
+		chars := "0123456789"
+		if pos < 4 {
+			chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+		}
+		for _, char := range chars {
+			complete(prefix + string(char))
+		}
+	}
+	complete("")
+	return contNums, nil
+}
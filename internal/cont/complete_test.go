@@ -0,0 +1,136 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	isAny := func(string) bool { return true }
+	isOneOf := func(values ...string) func(string) bool {
+		return func(value string) bool {
+			for _, v := range values {
+				if v == value {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	tests := []struct {
+		name         string
+		pattern      string
+		isOwnerCode  func(string) bool
+		isEquipCatID func(string) bool
+		want         []Number
+		wantErr      bool
+	}{
+		{
+			"Complete serial number and check digit",
+			"ABCU12?456?",
+			isOneOf("ABC"),
+			isOneOf("U"),
+			[]Number{
+				newNum("ABC", "U", "120456", 6),
+				newNum("ABC", "U", "121456", 4),
+				newNum("ABC", "U", "122456", 2),
+				newNum("ABC", "U", "123456", 0),
+				newNum("ABC", "U", "124456", 9),
+				newNum("ABC", "U", "125456", 7),
+				newNum("ABC", "U", "126456", 5),
+				newNum("ABC", "U", "127456", 3),
+				newNum("ABC", "U", "128456", 1),
+				newNum("ABC", "U", "129456", 0),
+			},
+			false,
+		},
+		{
+			"Complete registered owner codes",
+			"??CU1234560",
+			isOneOf("ABC", "NYC", "XYC", "XYZ"),
+			isOneOf("U"),
+			[]Number{
+				newNum("ABC", "U", "123456", 0),
+				newNum("NYC", "U", "123456", 0),
+				newNum("XYC", "U", "123456", 0),
+			},
+			false,
+		},
+		{
+			"Complete equipment category ID",
+			"abc?1234560",
+			isAny,
+			isOneOf("U", "J", "Z"),
+			[]Number{newNum("ABC", "U", "123456", 0)},
+			false,
+		},
+		{
+			"Complete serial number with check digit 10",
+			"ABCU12345?0",
+			isAny,
+			isAny,
+			[]Number{
+				newNum("ABC", "U", "123454", 0),
+				newNum("ABC", "U", "123456", 0),
+			},
+			false,
+		},
+		{
+			"Complete unregistered owner code",
+			"XYZU12345?0",
+			isOneOf("ABC"),
+			isAny,
+			[]Number{},
+			false,
+		},
+		{
+			"Complete returns error for digit in owner code",
+			"A1CU12345?0",
+			isAny,
+			isAny,
+			nil,
+			true,
+		},
+		{
+			"Complete returns error for too many placeholders",
+			"???U??????0",
+			isAny,
+			isAny,
+			nil,
+			true,
+		},
+		{
+			"Complete returns error for wrong length",
+			"ABCU12345?",
+			isAny,
+			isAny,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Complete(tt.pattern, tt.isOwnerCode, tt.isEquipCatID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Complete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Complete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"sort"
+	"strconv"
+)
+
+// serialNumWeights are the weights of the serial number digits modulo 11 as used
+// in the check digit calculation.
+var serialNumWeights = [6]int{16 % 11, 32 % 11, 64 % 11, 128 % 11, 256 % 11, 512 % 11}
+
+// hasMinDistance returns true if the container number differs from every
+// selected container number in at least minDistance positions.
+func hasMinDistance(contNum Number, selected []Number, minDistance int) bool {
+	for _, s := range selected {
+		if distance(contNum, s) < minDistance {
+			return false
+		}
+	}
+	return true
+}
+
+// distance returns the count of positions in which the container numbers differ.
+func distance(a, b Number) int {
+	d := 0
+	for i := 0; i < 3; i++ {
+		if a.ownerCode[i] != b.ownerCode[i] {
+			d++
+		}
+	}
+	if a.equipCatID != b.equipCatID {
+		d++
+	}
+	for i := 0; i < 6; i++ {
+		if a.serialNumber[i] != b.serialNumber[i] {
+			d++
+		}
+	}
+	if a.checkDigit != b.checkDigit {
+		d++
+	}
+	return d
+}
+
+// maxDistance returns an upper bound of the count of positions in which two
+// container numbers of the owner codes differ. The equipment category ID is the
+// same for all container numbers, so it never differs.
+func (gb *GeneratorBuilder) maxDistance(codes []string) int {
+	// check digit
+	maxDistance := 1
+	for _, size := range gb.serialNumAlphabetSizes() {
+		if size > 1 {
+			maxDistance++
+		}
+	}
+	for pos := 0; pos < 3; pos++ {
+		for _, code := range codes {
+			if code[pos] != codes[0][pos] {
+				maxDistance++
+				break
+			}
+		}
+	}
+	return maxDistance
+}
+
+// distanceBound returns the Singleton bound of the count of container numbers with
+// minimum distance. After deleting minDistance - 1 positions the remaining positions
+// of the container numbers still differ, so there are not more container numbers
+// than values of the remaining positions.
+func (gb *GeneratorBuilder) distanceBound(codes []string, candidates int) int {
+	deleted := gb.minDistance - 1
+	// The check digit has 10 values. The largest alphabets are deleted first.
+	sizes := append([]int{10}, gb.serialNumAlphabetSizes()...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+	bound := candidates
+	for ownerDeleted := 0; ownerDeleted <= 3 && ownerDeleted <= deleted; ownerDeleted++ {
+		rest := deleted - ownerDeleted
+		if rest > len(sizes) {
+			rest = len(sizes)
+		}
+		b := ownerProjections(codes, ownerDeleted)
+		for _, size := range sizes[rest:] {
+			b *= size
+		}
+		if b < bound {
+			bound = b
+		}
+	}
+	return bound
+}
+
+// serialNumAlphabetSizes returns the count of allowed digits of every serial number position.
+func (gb *GeneratorBuilder) serialNumAlphabetSizes() []int {
+	mask := anySerialNumMask()
+	if gb.mask != nil {
+		mask = *gb.mask
+	}
+	var sizes []int
+	for _, allowed := range mask.allowed {
+		size := 0
+		for _, ok := range allowed {
+			if ok {
+				size++
+			}
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes
+}
+
+// ownerProjections returns the lowest count of distinct owner codes after
+// deleting count positions.
+func ownerProjections(codes []string, count int) int {
+	lowest := len(codes)
+	for positions := 0; positions < 1<<3; positions++ {
+		if bitCount(positions) != count {
+			continue
+		}
+		projections := map[string]bool{}
+		for _, code := range codes {
+			b := []byte(code)
+			for pos := range b {
+				if positions&(1<<pos) != 0 {
+					b[pos] = '_'
+				}
+			}
+			projections[string(b)] = true
+		}
+		if len(projections) < lowest {
+			lowest = len(projections)
+		}
+	}
+	return lowest
+}
+
+func bitCount(x int) int {
+	count := 0
+	for ; x > 0; x >>= 1 {
+		count += x & 1
+	}
+	return count
+}
+
+// serialNumBallSize returns the count of serial numbers that differ from a serial
+// number in at most radius positions.
+func serialNumBallSize(radius int) int {
+	size, combinations, values := 0, 1, 1
+	for differing := 0; differing <= radius && differing <= 6; differing++ {
+		size += combinations * values
+		combinations = combinations * (6 - differing) / (differing + 1)
+		values *= 9
+	}
+	return size
+}
+
+// distanceBlocker marks all container numbers that are closer than the minimum
+// distance to a selected container number. Checking a candidate does not depend
+// on the count of selected container numbers.
+type distanceBlocker struct {
+	codes      []string
+	ownerIdx   map[string]int
+	remainders []int
+	mask       *SerialNumMask
+	radius     int
+	blocked    [][]*blockedPage
+	neighbors  map[int][]ownerNeighbor
+}
+
+// blockedPageBits is the count of low bits of a serial number that address it in a page.
+const blockedPageBits = 12
+
+// blockedPage marks blocked serial numbers of an owner code with the same high bits.
+// Pages are allocated when a serial number in them is blocked, so only close
+// serial numbers of selected container numbers take memory.
+type blockedPage [1 << blockedPageBits / 64]uint64
+
+// ownerNeighbor is an owner code with its distance to another owner code.
+type ownerNeighbor struct {
+	idx      int
+	distance int
+}
+
+func newDistanceBlocker(codes []string, equipCatID string, mask *SerialNumMask, minDistance int) *distanceBlocker {
+	b := &distanceBlocker{
+		codes:     codes,
+		ownerIdx:  make(map[string]int, len(codes)),
+		mask:      mask,
+		radius:    minDistance - 1,
+		blocked:   make([][]*blockedPage, len(codes)),
+		neighbors: map[int][]ownerNeighbor{},
+	}
+	for idx, code := range codes {
+		b.ownerIdx[code] = idx
+		b.remainders = append(b.remainders, CalcCheckDigit(code, equipCatID, "000000"))
+	}
+	return b
+}
+
+// isBlocked returns true if the container number is closer than the minimum
+// distance to a selected container number.
+func (b *distanceBlocker) isBlocked(contNum Number) bool {
+	num, _ := strconv.Atoi(contNum.serialNumber)
+	pages := b.blocked[b.ownerIdx[contNum.ownerCode]]
+	if pages == nil || pages[num>>blockedPageBits] == nil {
+		return false
+	}
+	offset := num & (1<<blockedPageBits - 1)
+	return pages[num>>blockedPageBits][offset>>6]&(1<<uint(offset&63)) != 0
+}
+
+// block marks all container numbers that differ from the selected container
+// number in at most radius positions.
+func (b *distanceBlocker) block(contNum Number) {
+	var digits [6]int
+	for pos := range digits {
+		digits[pos] = int(contNum.serialNumber[pos] - '0')
+	}
+	for _, neighbor := range b.ownerNeighbors(b.ownerIdx[contNum.ownerCode]) {
+		b.blockSerialNums(neighbor, digits, contNum.checkDigit, 0, 0, b.remainders[neighbor.idx], 0)
+	}
+}
+
+// blockSerialNums changes the digits from position pos on recursively and marks
+// the serial numbers whose container numbers are not farther than radius.
+func (b *distanceBlocker) blockSerialNums(neighbor ownerNeighbor, digits [6]int, checkDigit,
+	pos, num, remainder, changed int) {
+	if neighbor.distance+changed == b.radius {
+		// No further digit may change, so only the serial number with the same
+		// remaining digits and the same check digit is blocked.
+		for ; pos < 6; pos++ {
+			if b.mask != nil && !b.mask.allowed[pos][digits[pos]] {
+				return
+			}
+			num = num*10 + digits[pos]
+			remainder += digits[pos] * serialNumWeights[pos]
+		}
+		if remainder%11%10 == checkDigit {
+			b.blockSerialNum(neighbor.idx, num)
+		}
+		return
+	}
+	if pos == 6 {
+		distance := neighbor.distance + changed
+		if remainder%11%10 != checkDigit {
+			distance++
+		}
+		if distance <= b.radius {
+			b.blockSerialNum(neighbor.idx, num)
+		}
+		return
+	}
+	for digit := 0; digit < 10; digit++ {
+		c := changed
+		if digit != digits[pos] {
+			c++
+		}
+		if neighbor.distance+c > b.radius || b.mask != nil && !b.mask.allowed[pos][digit] {
+			continue
+		}
+		b.blockSerialNums(neighbor, digits, checkDigit,
+			pos+1, num*10+digit, remainder+digit*serialNumWeights[pos], c)
+	}
+}
+
+// blockSerialNum marks the serial number of the owner code index.
+func (b *distanceBlocker) blockSerialNum(idx, num int) {
+	if b.blocked[idx] == nil {
+		b.blocked[idx] = make([]*blockedPage, 1000000>>blockedPageBits+1)
+	}
+	page := b.blocked[idx][num>>blockedPageBits]
+	if page == nil {
+		page = &blockedPage{}
+		b.blocked[idx][num>>blockedPageBits] = page
+	}
+	offset := num & (1<<blockedPageBits - 1)
+	page[offset>>6] |= 1 << uint(offset&63)
+}
+
+// ownerNeighbors returns the owner codes that differ from the owner code in at
+// most radius positions.
+func (b *distanceBlocker) ownerNeighbors(idx int) []ownerNeighbor {
+	if neighbors, ok := b.neighbors[idx]; ok {
+		return neighbors
+	}
+	var neighbors []ownerNeighbor
+	for i, code := range b.codes {
+		d := 0
+		for pos := 0; pos < 3; pos++ {
+			if code[pos] != b.codes[idx][pos] {
+				d++
+			}
+		}
+		if d <= b.radius {
+			neighbors = append(neighbors, ownerNeighbor{idx: i, distance: d})
+		}
+	}
+	b.neighbors[idx] = neighbors
+	return neighbors
+}
@@ -17,7 +17,10 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -25,11 +28,18 @@ import (
 // Use NewUniqueGeneratorBuilder to create a new one.
 type GeneratorBuilder struct {
 	codes                []string
+	equipCatID           string
+	seed                 int64
 	count                int
 	start                int
 	end                  int
 	exclCheckDigit10     bool
 	exclTranspositionErr bool
+	excluded             []Number
+	weights              map[string]int
+	mask                 *SerialNumMask
+	minDistance          int
+	workers              int
 }
 
 // NewUniqueGeneratorBuilder returns a new random unique container number generator.
@@ -37,9 +47,12 @@ type GeneratorBuilder struct {
 // no owner codes are passed then nil and error is returned.
 func NewUniqueGeneratorBuilder() *GeneratorBuilder {
 	return &GeneratorBuilder{
-		count: 1,
-		start: -1,
-		end:   -1,
+		equipCatID: "U",
+		seed:       time.Now().UnixNano(),
+		count:      1,
+		start:      -1,
+		end:        -1,
+		workers:    1,
 	}
 }
 
@@ -49,6 +62,20 @@ func (gb *GeneratorBuilder) OwnerCodes(codes []string) *GeneratorBuilder {
 	return gb
 }
 
+// EquipCatID sets the equipment category ID for generation. Default is U.
+func (gb *GeneratorBuilder) EquipCatID(equipCatID string) *GeneratorBuilder {
+	gb.equipCatID = equipCatID
+	return gb
+}
+
+// Seed sets the seed for the pseudo random generation of serial numbers and the
+// order of owner codes. The same seed and options always generate the same container
+// numbers. Default is the current time.
+func (gb *GeneratorBuilder) Seed(seed int64) *GeneratorBuilder {
+	gb.seed = seed
+	return gb
+}
+
 // Count sets the count of container number.
 func (gb *GeneratorBuilder) Count(count int) *GeneratorBuilder {
 	gb.count = count
@@ -79,6 +106,45 @@ func (gb *GeneratorBuilder) ExcludeTranspositionErr(exclude bool) *GeneratorBuil
 	return gb
 }
 
+// Exclude sets container numbers that are never generated, e.g. numbers already
+// in use by a fleet. The check digit of an excluded container number is ignored.
+func (gb *GeneratorBuilder) Exclude(contNums []Number) *GeneratorBuilder {
+	gb.excluded = append(gb.excluded, contNums...)
+	return gb
+}
+
+// OwnerWeights sets weights of owner codes for pseudo random serial numbers. An owner
+// code with weight 3 is chosen 3 times as often as an owner code with weight 1.
+// Owner codes without a weight have weight 1.
+func (gb *GeneratorBuilder) OwnerWeights(weights map[string]int) *GeneratorBuilder {
+	gb.weights = weights
+	return gb
+}
+
+// SerialNumMask sets the mask that every generated serial number matches.
+func (gb *GeneratorBuilder) SerialNumMask(mask SerialNumMask) *GeneratorBuilder {
+	gb.mask = &mask
+	return gb
+}
+
+// MinDistance sets the minimum count of positions in which every pair of generated
+// container numbers differs. Owner code, equipment category ID, serial number and
+// check digit have 11 positions. Container numbers are chosen greedily in the order of
+// the serial number iterator. Build returns an error if the minimum distance exceeds
+// the positions that differ or count exceeds the Singleton bound, and an error with
+// the achievable count if count container numbers with minimum distance are not found.
+func (gb *GeneratorBuilder) MinDistance(minDistance int) *GeneratorBuilder {
+	gb.minDistance = minDistance
+	return gb
+}
+
+// Workers sets the count of goroutines that check candidates in GenerateBatch.
+// The generated container numbers are the same for every count of workers.
+func (gb *GeneratorBuilder) Workers(workers int) *GeneratorBuilder {
+	gb.workers = workers
+	return gb
+}
+
 // Build returns a new UniqueGenerator if all requirements met.
 // Valid combinations a
 func (gb *GeneratorBuilder) Build() (*UniqueGenerator, error) {
@@ -89,27 +155,43 @@ func (gb *GeneratorBuilder) Build() (*UniqueGenerator, error) {
 		return nil, errors.New("cannot generate container numbers without owner codes")
 	}
 
-	serialNums := 1000000
+	if err := IsEquipCatID(gb.equipCatID); err != nil {
+		return nil, err
+	}
 
-	if gb.exclCheckDigit10 {
-		serialNums = 909091
+	codes := make([]string, lenCodes)
+	for i, code := range gb.codes {
+		codes[i] = strings.ToUpper(code)
 	}
 
-	if gb.count > lenCodes*serialNums {
-		return nil, fmt.Errorf("count %d exceeds limit of %d (%d owners * %d serial numbers)",
-			gb.count, lenCodes*serialNums, lenCodes, serialNums)
+	excluded := gb.relevantExcluded(codes)
+
+	if maxDistance := gb.maxDistance(codes); gb.minDistance > maxDistance {
+		return nil, fmt.Errorf("minimum distance %d exceeds maximum distance %d", gb.minDistance, maxDistance)
+	}
+
+	if gb.workers < 1 {
+		return nil, fmt.Errorf("workers %d is lower than 1", gb.workers)
+	}
+
+	limit := gb.limit(codes) - len(excluded)
+	if gb.count > limit {
+		if len(excluded) > 0 {
+			return nil, fmt.Errorf("count %d exceeds limit of %d serial numbers of %d owners (%d excluded)",
+				gb.count, limit, lenCodes, len(excluded))
+		}
+		return nil, fmt.Errorf("count %d exceeds limit of %d serial numbers of %d owners",
+			gb.count, limit, lenCodes)
 	}
 
+	rnd := rand.New(rand.NewSource(gb.seed))
+
 	var serialNumIt serialNumIt
 	var count int
 
 	if gb.start > -1 && gb.end > -1 {
 		serialNumIt = newSeqSerialNumIt(gb.start)
-		if gb.start <= gb.end {
-			count = gb.end + 1 - gb.start
-		} else {
-			count = gb.end + 1000000 + 1 - gb.start
-		}
+		count = rangeLen(gb.start, gb.end)
 	}
 
 	if gb.start > -1 && gb.end == -1 {
@@ -118,7 +200,10 @@ func (gb *GeneratorBuilder) Build() (*UniqueGenerator, error) {
 	}
 
 	if gb.end > -1 && gb.start == -1 {
-		serialNumIt = newSeqSerialNumIt(gb.end + 1 - gb.count)
+		if gb.count < 1 {
+			return nil, fmt.Errorf("count %d is lower than minimum count 1", gb.count)
+		}
+		// The start depends on the owner codes and is set after shuffling them.
 		count = gb.count
 	}
 
@@ -126,22 +211,156 @@ func (gb *GeneratorBuilder) Build() (*UniqueGenerator, error) {
 		if gb.count < 1 {
 			return nil, fmt.Errorf("count %d is lower than minimum count 1", gb.count)
 		}
-		serialNumIt = newRandSerialNumIt()
+		serialNumIt = newRandSerialNumIt(rnd)
 		count = gb.count
 	}
 
-	rand.Shuffle(lenCodes, func(i, j int) {
-		gb.codes[i], gb.codes[j] = strings.ToUpper(gb.codes[j]), strings.ToUpper(gb.codes[i])
+	// Sorting makes the order of owner codes independent of the passed order.
+	sort.Strings(codes)
+	rnd.Shuffle(lenCodes, func(i, j int) {
+		codes[i], codes[j] = codes[j], codes[i]
 	})
 
-	return &UniqueGenerator{
-		codes:                gb.codes,
+	var weighted *weightedOwners
+	if len(gb.weights) > 0 {
+		if gb.start > -1 || gb.end > -1 {
+			return nil, errors.New("owner weights are only supported for pseudo random serial numbers")
+		}
+		var err error
+		weighted, err = newWeightedOwners(rnd, codes, gb.weights)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	g := &UniqueGenerator{
+		codes:                codes,
 		lenCodes:             lenCodes,
+		equipCatID:           gb.equipCatID,
 		serialNumIt:          serialNumIt,
 		count:                count,
 		exclCheckDigit10:     gb.exclCheckDigit10,
 		exclTranspositionErr: gb.exclTranspositionErr,
-	}, nil
+		excluded:             excluded,
+		weighted:             weighted,
+		mask:                 gb.mask,
+		workers:              gb.workers,
+	}
+	if weighted == nil {
+		// Every owner code is paired once with every serial number.
+		g.candidates = lenCodes * 1000000
+	}
+	if gb.end > -1 && gb.start == -1 {
+		start, err := g.acceptedStart(gb.end, gb.count)
+		if err != nil {
+			return nil, err
+		}
+		g.serialNumIt = newSeqSerialNumIt(start)
+		g.candidates = rangeLen(start, gb.end)
+	}
+	if gb.start > -1 && gb.end > -1 {
+		// Candidates after the end are never generated. The count is only reached
+		// if no candidate in the range is rejected.
+		g.candidates = rangeLen(gb.start, gb.end)
+		g.isRange = true
+	}
+
+	if gb.minDistance < 2 {
+		return g, nil
+	}
+	if gb.start == -1 || gb.end == -1 {
+		// All container numbers are candidates.
+		g.count = limit
+	}
+	if bound := gb.distanceBound(codes, g.count); gb.count > bound {
+		return nil, fmt.Errorf("count %d exceeds upper bound %d of container numbers with minimum distance %d",
+			gb.count, bound, gb.minDistance)
+	}
+	// Blocking all close container numbers of a selected container number is
+	// cheaper than comparing every candidate with all selected container numbers
+	// unless the minimum distance is high.
+	var blocker *distanceBlocker
+	if lenCodes*serialNumBallSize(gb.minDistance-1) < g.count {
+		blocker = newDistanceBlocker(codes, gb.equipCatID, gb.mask, gb.minDistance)
+	}
+	var selected []Number
+	// The scan stops if no candidate is selected for a long time, because
+	// scanning all candidates of many owner codes takes too long.
+	rejected := 0
+	for len(selected) < gb.count && rejected < maxRejectedCandidates && g.Generate() {
+		if blocker != nil {
+			if blocker.isBlocked(g.contNum) {
+				rejected++
+				continue
+			}
+			blocker.block(g.contNum)
+		} else if !hasMinDistance(g.contNum, selected, gb.minDistance) {
+			rejected++
+			continue
+		}
+		selected = append(selected, g.contNum)
+		rejected = 0
+	}
+	if len(selected) < gb.count {
+		return nil, fmt.Errorf("count %d exceeds achievable count %d of container numbers with minimum distance %d",
+			gb.count, len(selected), gb.minDistance)
+	}
+	return &UniqueGenerator{pending: selected, count: len(selected)}, nil
+}
+
+// maxRejectedCandidates is the count of consecutively rejected candidates after
+// which the selection of container numbers with minimum distance stops.
+const maxRejectedCandidates = 1000000
+
+// limit returns the count of serial numbers of all owner codes that match the mask
+// and have no check digit 10 if excluded.
+func (gb *GeneratorBuilder) limit(codes []string) int {
+	mask := anySerialNumMask()
+	if gb.mask != nil {
+		mask = *gb.mask
+	}
+	countByRemainder := mask.countByRemainder()
+	limit := 0
+	for _, code := range codes {
+		limit += mask.Count()
+		if gb.exclCheckDigit10 {
+			// Check digit is 10 if remainder of owner code and equipment category ID
+			// and remainder of serial number sum up to 10 modulo 11.
+			remainder := CalcCheckDigit(code, gb.equipCatID, "000000")
+			limit -= countByRemainder[(10-remainder+11)%11]
+		}
+	}
+	return limit
+}
+
+// relevantExcluded returns the excluded container numbers that could be generated
+// with the owner codes and equipment category ID of the builder.
+func (gb *GeneratorBuilder) relevantExcluded(codes []string) map[string]bool {
+	if len(gb.excluded) == 0 {
+		return nil
+	}
+	isCode := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		isCode[code] = true
+	}
+	excluded := map[string]bool{}
+	for _, contNum := range gb.excluded {
+		if !isCode[contNum.ownerCode] || contNum.equipCatID != gb.equipCatID {
+			continue
+		}
+		if serialNum, _ := strconv.Atoi(contNum.serialNumber); gb.mask != nil && !gb.mask.Matches(serialNum) {
+			continue
+		}
+		if gb.exclCheckDigit10 && CalcCheckDigit(contNum.ownerCode, contNum.equipCatID, contNum.serialNumber) == 10 {
+			continue
+		}
+		excluded[excludedKey(contNum.ownerCode, contNum.equipCatID, contNum.serialNumber)] = true
+	}
+	return excluded
+}
+
+func excludedKey(ownerCode, equipCatID, serialNum string) string {
+	return ownerCode + equipCatID + serialNum
 }
 
 // UniqueGenerator holds state for generating random unique container numbers.
@@ -149,6 +368,7 @@ func (gb *GeneratorBuilder) Build() (*UniqueGenerator, error) {
 type UniqueGenerator struct {
 	codes                []string
 	lenCodes             int
+	equipCatID           string
 	ownerOffset          int
 	serialNumIt          serialNumIt
 	count                int
@@ -156,30 +376,205 @@ type UniqueGenerator struct {
 	generatedCount       int
 	exclCheckDigit10     bool
 	exclTranspositionErr bool
+	excluded             map[string]bool
+	weighted             *weightedOwners
+	mask                 *SerialNumMask
+	workers              int
+	pending              []Number
+	candidates           int
+	isRange              bool
+	err                  error
+}
+
+// candidate is an owner code and serial number which is not yet checked.
+type candidate struct {
+	code string
+	num  int
 }
 
+// candidateChunkSize is the minimal count of candidates checked concurrently.
+const candidateChunkSize = 4096
+
 // Generate advances the serial number iterator to the next serial number,
 // which will then be available through the ContNum method. It returns false
 // when the generation stops by reaching the count of generated container numbers.
 func (g *UniqueGenerator) Generate() bool {
-	code := g.codes[(g.serialNumIt.num()+g.ownerOffset)%g.lenCodes]
-	serialNum := fmt.Sprintf("%06d", g.serialNumIt.num())
-	checkDigit := CalcCheckDigit(code, "U", serialNum)
+	if g.generatedCount >= g.count {
+		return false
+	}
+	contNum, ok := g.nextAccepted()
+	if !ok {
+		g.err = g.exhaustedErr()
+		return false
+	}
+	g.contNum = contNum
+	g.generatedCount++
+	return true
+}
 
-	if g.serialNumIt.isLast() {
-		g.ownerOffset++
+// GenerateBatch fills batch with generated container numbers and returns the
+// count of filled container numbers. It returns 0 when the generation stops by
+// reaching the count of generated container numbers. With more than one worker
+// the candidates are checked concurrently.
+func (g *UniqueGenerator) GenerateBatch(batch []Number) int {
+	n := min(len(batch), g.count-g.generatedCount)
+	if n <= 0 {
+		return 0
 	}
-	g.serialNumIt.increment()
+	if g.workers > 1 {
+		g.fillPending(n)
+	}
+	filled := 0
+	for filled < n {
+		contNum, ok := g.nextAccepted()
+		if !ok {
+			break
+		}
+		batch[filled] = contNum
+		filled++
+	}
+	g.generatedCount += filled
+	if filled > 0 {
+		g.contNum = batch[filled-1]
+	}
+	if filled < n {
+		g.err = g.exhaustedErr()
+	}
+	return filled
+}
+
+// Err returns an error if the generation stopped before count container numbers
+// were generated because all candidates are used.
+func (g *UniqueGenerator) Err() error {
+	return g.err
+}
+
+func (g *UniqueGenerator) exhaustedErr() error {
+	if g.isRange {
+		return nil
+	}
+	return fmt.Errorf("count %d exceeds %d available container numbers", g.count, g.generatedCount)
+}
+
+// nextAccepted returns the next pending container number or the next candidate
+// that is not excluded. It returns false if all candidates are used.
+func (g *UniqueGenerator) nextAccepted() (Number, bool) {
+	if len(g.pending) > 0 {
+		contNum := g.pending[0]
+		g.pending = g.pending[1:]
+		return contNum, true
+	}
+	for {
+		if g.isExhausted() {
+			return Number{}, false
+		}
+		code, num := g.next()
+		if contNum, ok := g.accept(code, num); ok {
+			return contNum, true
+		}
+	}
+}
+
+// fillPending checks candidates concurrently until at least n container
+// numbers are pending or all candidates are used. Candidates are drawn
+// sequentially, so their order and uniqueness do not depend on the workers.
+func (g *UniqueGenerator) fillPending(n int) {
+	for len(g.pending) < n && !g.isExhausted() {
+		size := n - len(g.pending)
+		if size < candidateChunkSize {
+			size = candidateChunkSize
+		}
+		candidates := make([]candidate, 0, size)
+		for len(candidates) < size && !g.isExhausted() {
+			code, num := g.next()
+			candidates = append(candidates, candidate{code: code, num: num})
+		}
+		g.pending = append(g.pending, g.acceptConcurrently(candidates)...)
+	}
+}
+
+// acceptConcurrently splits the candidates into one part per worker and
+// returns the accepted container numbers in the order of the candidates.
+func (g *UniqueGenerator) acceptConcurrently(candidates []candidate) []Number {
+	contNums := make([]Number, len(candidates))
+	accepted := make([]bool, len(candidates))
+	partSize := (len(candidates) + g.workers - 1) / g.workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(candidates); start += partSize {
+		end := min(start+partSize, len(candidates))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				contNums[i], accepted[i] = g.accept(candidates[i].code, candidates[i].num)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	result := contNums[:0]
+	for i, contNum := range contNums {
+		if accepted[i] {
+			result = append(result, contNum)
+		}
+	}
+	return result
+}
+
+// accept returns the container number of the owner code and serial number and
+// true if it is not excluded. It only reads the generator and is safe for
+// concurrent use.
+func (g *UniqueGenerator) accept(code string, num int) (Number, bool) {
+	if g.mask != nil && !g.mask.Matches(num) {
+		return Number{}, false
+	}
+	serialNum := formatSerialNum(num)
+	checkDigit := CalcCheckDigit(code, g.equipCatID, serialNum)
 
 	if g.exclCheckDigit10 && checkDigit == 10 {
-		return g.Generate()
+		return Number{}, false
 	}
-	if g.exclTranspositionErr && len(CheckTransposition(code, "U", serialNum)) > 0 {
-		return g.Generate()
+	if g.exclTranspositionErr && len(CheckTransposition(code, g.equipCatID, serialNum)) > 0 {
+		return Number{}, false
 	}
-	g.contNum = newNum(code, "U", serialNum, checkDigit%10)
-	g.generatedCount++
-	return g.generatedCount <= g.count
+	if g.excluded[excludedKey(code, g.equipCatID, serialNum)] {
+		return Number{}, false
+	}
+	return newNum(code, g.equipCatID, serialNum, checkDigit%10), true
+}
+
+// acceptedStart returns the start of the sequential serial numbers up to end
+// that contain count accepted candidates.
+func (g *UniqueGenerator) acceptedStart(end, count int) (int, error) {
+	accepted := 0
+	for i := 0; i < 1000000; i++ {
+		num := (end - i + 1000000) % 1000000
+		if _, ok := g.accept(g.codes[num%g.lenCodes], num); ok {
+			accepted++
+			if accepted == count {
+				return num, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("count %d exceeds %d available container numbers up to end %d", count, accepted, end)
+}
+
+// isExhausted returns true if no candidates are left.
+func (g *UniqueGenerator) isExhausted() bool {
+	if g.weighted != nil {
+		return g.weighted.isEmpty()
+	}
+	return g.candidates == 0
+}
+
+// formatSerialNum formats the serial number with six digits and leading zeros
+// without the cost of fmt.
+func formatSerialNum(num int) string {
+	var b [6]byte
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = byte('0' + num%10)
+		num /= 10
+	}
+	return string(b[:])
 }
 
 // ContNum returns generated container number.
@@ -187,6 +582,90 @@ func (g *UniqueGenerator) ContNum() Number {
 	return g.contNum
 }
 
+// next returns the next owner code and serial number and advances the iterators.
+func (g *UniqueGenerator) next() (string, int) {
+	if g.weighted != nil {
+		return g.weighted.next()
+	}
+	code := g.codes[(g.serialNumIt.num()+g.ownerOffset)%g.lenCodes]
+	num := g.serialNumIt.num()
+	if g.serialNumIt.isLast() {
+		g.ownerOffset++
+	}
+	g.serialNumIt.increment()
+	g.candidates--
+	return code, num
+}
+
+// weightedOwners chooses owner codes by weight. Every owner code has its own
+// pseudo random serial number iterator and is removed when all serial numbers are used.
+type weightedOwners struct {
+	rnd          *rand.Rand
+	codes        []string
+	weights      []int
+	cumWeights   []int
+	serialNumIts []serialNumIt
+}
+
+func newWeightedOwners(rnd *rand.Rand, codes []string, weights map[string]int) (*weightedOwners, error) {
+	isCode := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		isCode[code] = true
+	}
+	upperWeights := make(map[string]int, len(weights))
+	for code, weight := range weights {
+		code = strings.ToUpper(code)
+		if !isCode[code] {
+			return nil, fmt.Errorf("weight for owner code %s that is not generated", code)
+		}
+		if weight < 1 {
+			return nil, fmt.Errorf("weight %d of owner code %s is lower than 1", weight, code)
+		}
+		upperWeights[code] = weight
+	}
+	w := &weightedOwners{rnd: rnd}
+	for _, code := range codes {
+		weight, ok := upperWeights[code]
+		if !ok {
+			weight = 1
+		}
+		w.codes = append(w.codes, code)
+		w.weights = append(w.weights, weight)
+		w.serialNumIts = append(w.serialNumIts, newRandSerialNumIt(rnd))
+	}
+	w.sumWeights()
+	return w, nil
+}
+
+func (w *weightedOwners) sumWeights() {
+	w.cumWeights = make([]int, len(w.weights))
+	sum := 0
+	for i, weight := range w.weights {
+		sum += weight
+		w.cumWeights[i] = sum
+	}
+}
+
+func (w *weightedOwners) isEmpty() bool {
+	return len(w.codes) == 0
+}
+
+func (w *weightedOwners) next() (string, int) {
+	r := w.rnd.Intn(w.cumWeights[len(w.cumWeights)-1])
+	i := sort.Search(len(w.cumWeights), func(i int) bool { return w.cumWeights[i] > r })
+	code, it := w.codes[i], w.serialNumIts[i]
+	num := it.num()
+	if it.isLast() {
+		w.codes = append(w.codes[:i], w.codes[i+1:]...)
+		w.weights = append(w.weights[:i], w.weights[i+1:]...)
+		w.serialNumIts = append(w.serialNumIts[:i], w.serialNumIts[i+1:]...)
+		w.sumWeights()
+	} else {
+		it.increment()
+	}
+	return code, num
+}
+
 type serialNumIt interface {
 	num() int
 
@@ -200,14 +679,14 @@ type randSerialNumIt struct {
 	it         int
 }
 
-func newRandSerialNumIt() serialNumIt {
+func newRandSerialNumIt(rnd *rand.Rand) serialNumIt {
 	return &randSerialNumIt{
-		randOffset: rand.Int(),
+		randOffset: rnd.Int(),
 	}
 }
 
 func (r *randSerialNumIt) num() int {
-	return permSerialNum((permSerialNum(r.it) + r.randOffset) % 1000000)
+	return permSerialNum((permSerialNum(r.it%1000000) + r.randOffset) % 1000000)
 }
 
 func (r *randSerialNumIt) increment() {
@@ -215,7 +694,7 @@ func (r *randSerialNumIt) increment() {
 }
 
 func (r *randSerialNumIt) isLast() bool {
-	return r.it+1%1000000 == 1000000
+	return (r.it+1)%1000000 == 0
 }
 
 type seqSerialNumIt struct {
@@ -258,7 +737,3 @@ func permSerialNum(x int) int {
 	}
 	return prime - residue
 }
-
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
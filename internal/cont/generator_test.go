@@ -14,10 +14,12 @@
 package cont
 
 import (
-	"math/rand"
+	"fmt"
 	"reflect"
+	"runtime"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func TestGeneratorBuilder(t *testing.T) {
@@ -27,6 +29,7 @@ func TestGeneratorBuilder(t *testing.T) {
 		rangeStart       int
 		rangeEnd         int
 		exclCheckDigit10 bool
+		equipCatID       string
 	}
 	tests := []struct {
 		name    string
@@ -42,15 +45,19 @@ func TestGeneratorBuilder(t *testing.T) {
 				-1,
 				-1,
 				true,
+				"",
 			},
 			&UniqueGenerator{
-				codes:    []string{"ABC"},
-				lenCodes: 1,
+				codes:      []string{"ABC"},
+				lenCodes:   1,
+				equipCatID: "U",
 				serialNumIt: &randSerialNumIt{
 					randOffset: 5577006791947779410,
 				},
 				count:            2,
 				exclCheckDigit10: true,
+				workers:          1,
+				candidates:       1000000,
 			},
 			false,
 		},
@@ -65,8 +72,11 @@ func TestGeneratorBuilder(t *testing.T) {
 			&UniqueGenerator{
 				codes:       []string{"ABC"},
 				lenCodes:    1,
+				equipCatID:  "U",
 				serialNumIt: newSeqSerialNumIt(2),
 				count:       3,
+				workers:     1,
+				candidates:  1000000,
 			},
 			false,
 		},
@@ -81,8 +91,11 @@ func TestGeneratorBuilder(t *testing.T) {
 			&UniqueGenerator{
 				codes:       []string{"ABC"},
 				lenCodes:    1,
+				equipCatID:  "U",
 				serialNumIt: newSeqSerialNumIt(-1),
 				count:       4,
+				workers:     1,
+				candidates:  4,
 			},
 			false,
 		},
@@ -97,11 +110,48 @@ func TestGeneratorBuilder(t *testing.T) {
 			&UniqueGenerator{
 				codes:       []string{"ABC"},
 				lenCodes:    1,
+				equipCatID:  "U",
 				serialNumIt: newSeqSerialNumIt(2),
 				count:       4,
+				workers:     1,
+				candidates:  4,
+				isRange:     true,
+			},
+			false,
+		},
+		{
+			"Build unique container number generator with equipment category ID J",
+			fields{
+				codes:      []string{"ABC"},
+				count:      1,
+				rangeStart: 2,
+				rangeEnd:   2,
+				equipCatID: "J",
+			},
+			&UniqueGenerator{
+				codes:       []string{"ABC"},
+				lenCodes:    1,
+				equipCatID:  "J",
+				serialNumIt: newSeqSerialNumIt(2),
+				count:       1,
+				workers:     1,
+				candidates:  1,
+				isRange:     true,
 			},
 			false,
 		},
+		{
+			"Build returns error for invalid equipment category ID",
+			fields{
+				codes:      []string{"ABC"},
+				count:      1,
+				rangeStart: -1,
+				rangeEnd:   -1,
+				equipCatID: "1",
+			},
+			nil,
+			true,
+		},
 		{
 			"Build returns error for no owner codes",
 			fields{
@@ -125,13 +175,16 @@ func TestGeneratorBuilder(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			rand.Seed(1)
 			gb := NewUniqueGeneratorBuilder().
+				Seed(1).
 				OwnerCodes(tt.fields.codes).
 				Count(tt.fields.count).
 				Start(tt.fields.rangeStart).
 				End(tt.fields.rangeEnd).
 				ExcludeCheckDigit10(tt.fields.exclCheckDigit10)
+			if tt.fields.equipCatID != "" {
+				gb.EquipCatID(tt.fields.equipCatID)
+			}
 			got, err := gb.Build()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GeneratorBuilder.Build() error = %v, wantErr %v", err, tt.wantErr)
@@ -238,8 +291,8 @@ func TestUniqueGenerator(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			rand.Seed(1)
 			gb := NewUniqueGeneratorBuilder().
+				Seed(1).
 				OwnerCodes(tt.fields.codes).
 				Count(tt.fields.count).
 				Start(tt.fields.rangeStart).
@@ -273,3 +326,642 @@ func TestUniqueGenerator(t *testing.T) {
 		})
 	}
 }
+
+func TestUniqueGenerator_Seed(t *testing.T) {
+	generate := func(seed int64) []string {
+		g, err := NewUniqueGeneratorBuilder().
+			Seed(seed).
+			OwnerCodes([]string{"ABC", "DEF", "GHI"}).
+			Count(5).
+			Build()
+		if err != nil {
+			t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+		}
+		var contNums []string
+		for g.Generate() {
+			contNums = append(contNums, g.ContNum().String())
+		}
+		return contNums
+	}
+	if got, want := generate(42), generate(42); !reflect.DeepEqual(got, want) {
+		t.Errorf("UniqueGenerator.Generate() with same seed = %v, want %v", got, want)
+	}
+	if got, other := generate(42), generate(43); reflect.DeepEqual(got, other) {
+		t.Errorf("UniqueGenerator.Generate() with different seeds generated same numbers %v", got)
+	}
+}
+
+func TestUniqueGenerator_Exclude(t *testing.T) {
+	excluded := []Number{
+		newNum("ABC", "U", "000001", 7),
+		newNum("ABC", "U", "000003", 8),
+		newNum("ABC", "J", "000002", 5),
+		newNum("DEF", "U", "000002", 2),
+	}
+	tests := []struct {
+		name       string
+		count      int
+		rangeStart int
+		rangeEnd   int
+		want       []string
+	}{
+		{
+			"Skip excluded container numbers with start",
+			3,
+			0,
+			-1,
+			[]string{"ABC U 000000 1", "ABC U 000002 2", "ABC U 000004 3"},
+		},
+		{
+			"Skip excluded container numbers in range",
+			1,
+			0,
+			3,
+			[]string{"ABC U 000000 1", "ABC U 000002 2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewUniqueGeneratorBuilder().
+				Seed(1).
+				OwnerCodes([]string{"ABC"}).
+				Count(tt.count).
+				Start(tt.rangeStart).
+				End(tt.rangeEnd).
+				Exclude(excluded).
+				Build()
+			if err != nil {
+				t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+			}
+			var got []string
+			for g.Generate() {
+				got = append(got, g.ContNum().String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UniqueGenerator.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneratorBuilder_ExcludeLimit(t *testing.T) {
+	_, err := NewUniqueGeneratorBuilder().
+		OwnerCodes([]string{"ABC"}).
+		Count(999999).
+		Exclude([]Number{newNum("ABC", "U", "000001", 7), newNum("ABC", "U", "000003", 8)}).
+		Build()
+	want := "count 999999 exceeds limit of 999998 serial numbers of 1 owners (2 excluded)"
+	if err == nil || err.Error() != want {
+		t.Errorf("GeneratorBuilder.Build() error = %v, want %v", err, want)
+	}
+}
+
+func TestUniqueGenerator_OwnerWeights(t *testing.T) {
+	g, err := NewUniqueGeneratorBuilder().
+		Seed(1).
+		OwnerCodes([]string{"ABC", "DEF"}).
+		OwnerWeights(map[string]int{"ABC": 9}).
+		Count(1000).
+		Build()
+	if err != nil {
+		t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+	}
+	counts := map[string]int{}
+	contNums := map[string]bool{}
+	for g.Generate() {
+		contNum := g.ContNum()
+		if contNums[contNum.String()] {
+			t.Fatalf("UniqueGenerator.Generate() generated %v twice", contNum)
+		}
+		contNums[contNum.String()] = true
+		counts[contNum.ownerCode]++
+	}
+	if counts["ABC"]+counts["DEF"] != 1000 {
+		t.Errorf("UniqueGenerator.Generate() generated %d container numbers, want 1000", counts["ABC"]+counts["DEF"])
+	}
+	if counts["ABC"] < 850 || counts["ABC"] > 950 {
+		t.Errorf("UniqueGenerator.Generate() generated %d of 1000 container numbers with weight 9 of 10", counts["ABC"])
+	}
+}
+
+func TestGeneratorBuilder_OwnerWeightsErr(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights map[string]int
+		start   int
+	}{
+		{"Weight of owner code that is not generated", map[string]int{"XYZ": 2}, -1},
+		{"Weight lower than 1", map[string]int{"ABC": 0}, -1},
+		{"Weights with sequential serial numbers", map[string]int{"ABC": 2}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewUniqueGeneratorBuilder().
+				OwnerCodes([]string{"ABC", "DEF"}).
+				OwnerWeights(tt.weights).
+				Start(tt.start).
+				Build()
+			if err == nil {
+				t.Error("GeneratorBuilder.Build() returned no error")
+			}
+		})
+	}
+}
+
+func TestUniqueGenerator_SerialNumMask(t *testing.T) {
+	mask, _ := ParseSerialNumMask("4[0-1]*[^5]**")
+	g, err := NewUniqueGeneratorBuilder().
+		Seed(1).
+		OwnerCodes([]string{"ABC"}).
+		SerialNumMask(mask).
+		Count(100).
+		Build()
+	if err != nil {
+		t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+	}
+	generated := 0
+	for g.Generate() {
+		serialNum, _ := strconv.Atoi(g.ContNum().serialNumber)
+		if !mask.Matches(serialNum) {
+			t.Errorf("UniqueGenerator.Generate() generated %v that does not match mask %v", g.ContNum(), mask)
+		}
+		generated++
+	}
+	if generated != 100 {
+		t.Errorf("UniqueGenerator.Generate() generated %d container numbers, want 100", generated)
+	}
+}
+
+func TestGeneratorBuilder_SerialNumMaskLimit(t *testing.T) {
+	mask, _ := ParseSerialNumMask("4[0-1]*[^5]**")
+	want := 0
+	for serialNum := 0; serialNum < 1000000; serialNum++ {
+		if mask.Matches(serialNum) && CalcCheckDigit("ABC", "U", fmt.Sprintf("%06d", serialNum)) != 10 {
+			want++
+		}
+	}
+	gb := NewUniqueGeneratorBuilder().
+		OwnerCodes([]string{"ABC"}).
+		SerialNumMask(mask).
+		ExcludeCheckDigit10(true)
+	if _, err := gb.Count(want).Build(); err != nil {
+		t.Errorf("GeneratorBuilder.Build() of limit %d error = %v", want, err)
+	}
+	if _, err := gb.Count(want + 1).Build(); err == nil {
+		t.Errorf("GeneratorBuilder.Build() of count %d exceeding limit returned no error", want+1)
+	}
+}
+
+func TestUniqueGenerator_SerialNumMaskRange(t *testing.T) {
+	mask, _ := ParseSerialNumMask("*****[05]")
+	tests := []struct {
+		name       string
+		count      int
+		rangeStart int
+		rangeEnd   int
+		want       []string
+	}{
+		{
+			"Generate matching serial numbers in range",
+			1,
+			1,
+			12,
+			[]string{"ABC U 000005 9", "ABC U 000010 4"},
+		},
+		{
+			"Generate matching serial numbers before end",
+			2,
+			-1,
+			12,
+			[]string{"ABC U 000005 9", "ABC U 000010 4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewUniqueGeneratorBuilder().
+				OwnerCodes([]string{"ABC"}).
+				SerialNumMask(mask).
+				Count(tt.count).
+				Start(tt.rangeStart).
+				End(tt.rangeEnd).
+				Build()
+			if err != nil {
+				t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+			}
+			var got []string
+			for g.Generate() {
+				got = append(got, g.ContNum().String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UniqueGenerator.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniqueGenerator_RangeRejected(t *testing.T) {
+	want := []string{
+		"CSQ U 000000 1", "CSQ U 000001 7", "CSQ U 000002 2", "CSQ U 000003 8", "CSQ U 000004 3",
+		"CSQ U 000005 9", "CSQ U 000006 4", "CSQ U 000008 5", "CSQ U 000009 0",
+	}
+	tests := []struct {
+		name       string
+		count      int
+		rangeStart int
+		rangeEnd   int
+	}{
+		{"Stop at end of range", 0, 0, 9},
+		{"Start before end", 9, -1, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewUniqueGeneratorBuilder().
+				OwnerCodes([]string{"CSQ"}).
+				Count(tt.count).
+				Start(tt.rangeStart).
+				End(tt.rangeEnd).
+				ExcludeCheckDigit10(true).
+				Build()
+			if err != nil {
+				t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+			}
+			var got []string
+			for g.Generate() {
+				got = append(got, g.ContNum().String())
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("UniqueGenerator.Generate() = %v, want %v", got, want)
+			}
+			if err := g.Err(); err != nil {
+				t.Errorf("UniqueGenerator.Err() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestUniqueGenerator_MinDistance(t *testing.T) {
+	g, err := NewUniqueGeneratorBuilder().
+		Seed(1).
+		OwnerCodes([]string{"ABC", "DEF"}).
+		MinDistance(4).
+		Count(50).
+		Build()
+	if err != nil {
+		t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+	}
+	var contNums []Number
+	for g.Generate() {
+		for _, contNum := range contNums {
+			if d := distance(contNum, g.ContNum()); d < 4 {
+				t.Errorf("distance of %v and %v is %d, want at least 4", contNum, g.ContNum(), d)
+			}
+		}
+		contNums = append(contNums, g.ContNum())
+	}
+	if len(contNums) != 50 {
+		t.Errorf("UniqueGenerator.Generate() generated %d container numbers, want 50", len(contNums))
+	}
+}
+
+func TestGeneratorBuilder_MinDistanceErr(t *testing.T) {
+	tests := []struct {
+		name        string
+		codes       []string
+		count       int
+		rangeStart  int
+		rangeEnd    int
+		minDistance int
+		want        string
+	}{
+		{
+			"Error for minimum distance exceeding distance of serial number and check digit",
+			[]string{"ABC"},
+			2,
+			-1,
+			-1,
+			8,
+			"minimum distance 8 exceeds maximum distance 7",
+		},
+		{
+			"Error for minimum distance in range",
+			[]string{"ABC"},
+			5,
+			0,
+			9,
+			3,
+			"count 5 exceeds achievable count 1 of container numbers with minimum distance 3",
+		},
+		{
+			"Error for minimum distance exceeding maximum distance of one equipment category",
+			[]string{"ABC", "XYZ"},
+			5,
+			-1,
+			-1,
+			11,
+			"minimum distance 11 exceeds maximum distance 10",
+		},
+		{
+			"Error for count exceeding upper bound",
+			[]string{"ABC"},
+			11,
+			-1,
+			-1,
+			7,
+			"count 11 exceeds upper bound 10 of container numbers with minimum distance 7",
+		},
+		{
+			"Error for count exceeding achievable count",
+			[]string{"ABC"},
+			100000,
+			-1,
+			-1,
+			3,
+			"count 100000 exceeds achievable count 24189 of container numbers with minimum distance 3",
+		},
+		{
+			"Error for count exceeding achievable count of many owner codes",
+			testOwnerCodes(500),
+			60,
+			-1,
+			-1,
+			9,
+			"count 60 exceeds achievable count 36 of container numbers with minimum distance 9",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			_, err := NewUniqueGeneratorBuilder().
+				Seed(1).
+				OwnerCodes(tt.codes).
+				Count(tt.count).
+				Start(tt.rangeStart).
+				End(tt.rangeEnd).
+				MinDistance(tt.minDistance).
+				Build()
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("GeneratorBuilder.Build() error = %v, want %v", err, tt.want)
+			}
+			if elapsed := time.Since(start); elapsed > 20*time.Second {
+				t.Errorf("GeneratorBuilder.Build() took %v, want at most 20s", elapsed)
+			}
+		})
+	}
+}
+
+// testOwnerCodes returns count owner codes evenly spread from AAA to ZZZ.
+func testOwnerCodes(count int) []string {
+	codes := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		n := i * (26 * 26 * 26 / count)
+		codes = append(codes, string([]byte{byte('A' + n/676%26), byte('A' + n/26%26), byte('A' + n%26)}))
+	}
+	return codes
+}
+
+func Test_distanceBlocker(t *testing.T) {
+	mask, _ := ParseSerialNumMask("00[0-4]***")
+	codes := []string{"ABC", "ABD", "AXD", "XYZ"}
+	for minDistance := 3; minDistance <= 6; minDistance++ {
+		t.Run(strconv.Itoa(minDistance), func(t *testing.T) {
+			g, err := NewUniqueGeneratorBuilder().
+				Seed(1).
+				OwnerCodes(codes).
+				SerialNumMask(mask).
+				Count(4 * mask.Count()).
+				Build()
+			if err != nil {
+				t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+			}
+			blocker := newDistanceBlocker(g.codes, "U", &mask, minDistance)
+			var want, got []Number
+			for g.Generate() {
+				if hasMinDistance(g.ContNum(), want, minDistance) {
+					want = append(want, g.ContNum())
+				}
+				if !blocker.isBlocked(g.ContNum()) {
+					blocker.block(g.ContNum())
+					got = append(got, g.ContNum())
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("distanceBlocker selected %d container numbers, want %d", len(got), len(want))
+			}
+		})
+	}
+}
+
+func Test_serialNumBallSize(t *testing.T) {
+	for radius, want := range []int{1, 55, 1270, 15850, 114265, 468559, 1000000} {
+		if got := serialNumBallSize(radius); got != want {
+			t.Errorf("serialNumBallSize(%d) = %d, want %d", radius, got, want)
+		}
+	}
+}
+
+func Test_distance(t *testing.T) {
+	tests := []struct {
+		a    Number
+		b    Number
+		want int
+	}{
+		{newNum("ABC", "U", "123456", 0), newNum("ABC", "U", "123456", 0), 0},
+		{newNum("ABC", "U", "123456", 0), newNum("ABD", "U", "123457", 1), 3},
+		{newNum("ABC", "U", "123456", 0), newNum("DEF", "J", "654321", 9), 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a.String()+" "+tt.b.String(), func(t *testing.T) {
+			if got := distance(tt.a, tt.b); got != tt.want {
+				t.Errorf("distance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniqueGenerator_GenerateBatch(t *testing.T) {
+	newBuilder := func() *GeneratorBuilder {
+		return NewUniqueGeneratorBuilder().
+			Seed(1).
+			OwnerCodes([]string{"ABC", "DEF", "GHI"}).
+			Count(10000).
+			ExcludeCheckDigit10(true).
+			ExcludeTranspositionErr(true)
+	}
+	g, err := newBuilder().Build()
+	if err != nil {
+		t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+	}
+	var want []Number
+	for g.Generate() {
+		want = append(want, g.ContNum())
+	}
+
+	tests := []struct {
+		name      string
+		workers   int
+		batchSize int
+	}{
+		{"One worker and batch size 1", 1, 1},
+		{"One worker and batch size 333", 1, 333},
+		{"Four workers and batch size 1", 4, 1},
+		{"Four workers and batch size 333", 4, 333},
+		{"Four workers and batch size greater than count", 4, 20000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := newBuilder().Workers(tt.workers).Build()
+			if err != nil {
+				t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+			}
+			batch := make([]Number, tt.batchSize)
+			var got []Number
+			for n := g.GenerateBatch(batch); n > 0; n = g.GenerateBatch(batch) {
+				got = append(got, batch[:n]...)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("UniqueGenerator.GenerateBatch() generated %d container numbers different from Generate(), want %d",
+					len(got), len(want))
+			}
+		})
+	}
+}
+
+func TestUniqueGenerator_Exhausted(t *testing.T) {
+	mask, _ := ParseSerialNumMask("00000*")
+	tests := []struct {
+		name                 string
+		codes                []string
+		count                int
+		exclTranspositionErr bool
+		workers              int
+		want                 int
+		wantErr              bool
+	}{
+		{"Generate all serial numbers of 3 owner codes", []string{"ABC", "DEF", "GHI"}, 30, false, 1, 30, false},
+		{"Generate all serial numbers of 3 owner codes with workers", []string{"ABC", "DEF", "GHI"}, 30, false, 4, 30, false},
+		{"Stop after all candidates are used", []string{"CSQ"}, 10, true, 1, 9, true},
+		{"Stop after all candidates are used with workers", []string{"CSQ"}, 10, true, 4, 9, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewUniqueGeneratorBuilder().
+				Seed(1).
+				OwnerCodes(tt.codes).
+				Count(tt.count).
+				SerialNumMask(mask).
+				ExcludeTranspositionErr(tt.exclTranspositionErr).
+				Workers(tt.workers).
+				Build()
+			if err != nil {
+				t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+			}
+			contNums := map[string]bool{}
+			batch := make([]Number, 4)
+			for n := g.GenerateBatch(batch); n > 0; n = g.GenerateBatch(batch) {
+				for _, contNum := range batch[:n] {
+					if contNums[contNum.String()] {
+						t.Errorf("UniqueGenerator.GenerateBatch() generated %v twice", contNum)
+					}
+					contNums[contNum.String()] = true
+				}
+			}
+			if got := len(contNums); got != tt.want {
+				t.Errorf("UniqueGenerator.GenerateBatch() generated %d container numbers, want %d", got, tt.want)
+			}
+			if err := g.Err(); (err != nil) != tt.wantErr {
+				t.Errorf("UniqueGenerator.Err() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGeneratorBuilder_WorkersErr(t *testing.T) {
+	_, err := NewUniqueGeneratorBuilder().
+		OwnerCodes([]string{"ABC"}).
+		Workers(0).
+		Build()
+	if err == nil {
+		t.Errorf("GeneratorBuilder.Build() error = nil, want error for workers 0")
+	}
+}
+
+func Test_formatSerialNum(t *testing.T) {
+	tests := []struct {
+		num  int
+		want string
+	}{
+		{0, "000000"},
+		{42, "000042"},
+		{999999, "999999"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatSerialNum(tt.num); got != tt.want {
+				t.Errorf("formatSerialNum() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// benchmarkCount is the count of generated container numbers per benchmark operation.
+const benchmarkCount = 10000000
+
+// benchmarkOwnerCodes returns enough owner codes for benchmarkCount container
+// numbers without check digit 10 and transposition errors.
+func benchmarkOwnerCodes() []string {
+	var codes []string
+	for c := 'A'; c <= 'T'; c++ {
+		codes = append(codes, "AB"+string(c))
+	}
+	return codes
+}
+
+func newBenchmarkGenerator(b *testing.B, workers int) *UniqueGenerator {
+	g, err := NewUniqueGeneratorBuilder().
+		Seed(1).
+		OwnerCodes(benchmarkOwnerCodes()).
+		Count(benchmarkCount).
+		ExcludeCheckDigit10(true).
+		ExcludeTranspositionErr(true).
+		Workers(workers).
+		Build()
+	if err != nil {
+		b.Fatalf("GeneratorBuilder.Build() error = %v", err)
+	}
+	return g
+}
+
+func BenchmarkUniqueGenerator_Generate10M(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		g := newBenchmarkGenerator(b, 1)
+		n := 0
+		for g.Generate() {
+			n++
+		}
+		if n != benchmarkCount {
+			b.Fatalf("generated %d container numbers, want %d", n, benchmarkCount)
+		}
+	}
+}
+
+func benchmarkGenerateBatch10M(b *testing.B, workers int) {
+	batch := make([]Number, 4096)
+	for i := 0; i < b.N; i++ {
+		g := newBenchmarkGenerator(b, workers)
+		n := 0
+		for filled := g.GenerateBatch(batch); filled > 0; filled = g.GenerateBatch(batch) {
+			n += filled
+		}
+		if n != benchmarkCount {
+			b.Fatalf("generated %d container numbers, want %d", n, benchmarkCount)
+		}
+	}
+}
+
+func BenchmarkUniqueGenerator_GenerateBatch10M(b *testing.B) {
+	benchmarkGenerateBatch10M(b, 1)
+}
+
+func BenchmarkUniqueGenerator_GenerateBatch10MWorkers(b *testing.B) {
+	benchmarkGenerateBatch10M(b, runtime.NumCPU())
+}
@@ -14,7 +14,11 @@
 package cont
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // Number is a container number with needed properties to conform to the specified standard.
@@ -26,6 +30,26 @@ type Number struct {
 	sepOE, sepES, sepSC string
 }
 
+// OwnerCode returns the owner code.
+func (cn Number) OwnerCode() string {
+	return cn.ownerCode
+}
+
+// EquipCatID returns the equipment category ID.
+func (cn Number) EquipCatID() string {
+	return cn.equipCatID
+}
+
+// SerialNumber returns the serial number.
+func (cn Number) SerialNumber() string {
+	return cn.serialNumber
+}
+
+// CheckDigit returns the check digit. A calculated check digit 10 is returned as 0.
+func (cn Number) CheckDigit() int {
+	return cn.checkDigit
+}
+
 // SetSeparators sets separator for formatting for Stringer interface.
 func (cn *Number) SetSeparators(sepOE, sepES, sepSC string) {
 	cn.sepOE, cn.sepES, cn.sepSC = sepOE, sepES, sepSC
@@ -54,3 +78,136 @@ func newNum(ownerCode string,
 		sepSC:        " ",
 	}
 }
+
+// IsZero returns true if the container number is the zero value.
+func (cn Number) IsZero() bool {
+	return cn.ownerCode == "" && cn.equipCatID == "" && cn.serialNumber == ""
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The container number is encoded without separators, e.g. ABCU1234560.
+// The zero value is encoded as empty text.
+func (cn Number) MarshalText() ([]byte, error) {
+	if cn.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(fmt.Sprintf("%s%s%s%d", cn.ownerCode, cn.equipCatID, cn.serialNumber, cn.checkDigit)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// Spaces are ignored and an error is returned if the container number is not valid.
+// Empty text is decoded as the zero value.
+func (cn *Number) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*cn = Number{}
+		return nil
+	}
+	number, err := parseNum(string(text))
+	if err != nil {
+		return err
+	}
+	*cn = number
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The zero value is encoded as null.
+func (cn Number) MarshalJSON() ([]byte, error) {
+	if cn.IsZero() {
+		return []byte("null"), nil
+	}
+	text, err := cn.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// null is decoded as the zero value.
+func (cn *Number) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*cn = Number{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	return cn.UnmarshalText([]byte(s))
+}
+
+// Scan implements the sql.Scanner interface.
+// NULL is scanned as the zero value.
+func (cn *Number) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*cn = Number{}
+		return nil
+	case string:
+		return cn.UnmarshalText([]byte(value))
+	case []byte:
+		return cn.UnmarshalText(value)
+	}
+	return fmt.Errorf("cannot scan %T into container number", src)
+}
+
+// Value implements the driver.Valuer interface.
+// The zero value is stored as NULL.
+func (cn Number) Value() (driver.Value, error) {
+	if cn.IsZero() {
+		return nil, nil
+	}
+	text, err := cn.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+func parseNum(s string) (Number, error) {
+	s = strings.Replace(s, " ", "", -1)
+	if len(s) != 11 {
+		return Number{}, NewErrContValidate(fmt.Sprintf("%s is not 11 characters long", s))
+	}
+	number, err := parseNumParts(s)
+	if err != nil {
+		return Number{}, err
+	}
+	if !isDigits(s[10:]) {
+		return Number{}, NewErrContValidate(fmt.Sprintf("%s is not 1 digit", s[10:]))
+	}
+	checkDigit, _ := strconv.Atoi(s[10:])
+	if checkDigit != number.checkDigit {
+		return Number{}, NewErrContValidate(
+			fmt.Sprintf("%d is not calculated check digit %d", checkDigit, number.checkDigit))
+	}
+	return number, nil
+}
+
+// ParseIgnoringCheckDigit parses a container number with or without check digit.
+// Spaces are ignored. A passed check digit is neither validated nor used,
+// the container number always has the calculated check digit.
+func ParseIgnoringCheckDigit(s string) (Number, error) {
+	s = strings.Replace(s, " ", "", -1)
+	if len(s) != 10 && len(s) != 11 {
+		return Number{}, NewErrContValidate(fmt.Sprintf("%s is not 10 or 11 characters long", s))
+	}
+	return parseNumParts(s)
+}
+
+// parseNumParts parses owner code, equipment category ID and serial number of the
+// first 10 characters and calculates the check digit.
+func parseNumParts(s string) (Number, error) {
+	ownerCode, equipCatID, serialNum := s[0:3], s[3:4], s[4:10]
+	if err := IsOwnerCode(ownerCode); err != nil {
+		return Number{}, err
+	}
+	if err := IsEquipCatID(equipCatID); err != nil {
+		return Number{}, err
+	}
+	if !isDigits(serialNum) {
+		return Number{}, NewErrContValidate(fmt.Sprintf("%s is not 6 digits", serialNum))
+	}
+	return newNum(ownerCode, equipCatID, serialNum, CalcCheckDigit(ownerCode, equipCatID, serialNum)%10), nil
+}
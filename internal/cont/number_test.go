@@ -0,0 +1,196 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNumber_Accessors(t *testing.T) {
+	number := newNum("ABC", "U", "123456", 0)
+	if got := number.OwnerCode(); got != "ABC" {
+		t.Errorf("OwnerCode() = %v, want %v", got, "ABC")
+	}
+	if got := number.EquipCatID(); got != "U" {
+		t.Errorf("EquipCatID() = %v, want %v", got, "U")
+	}
+	if got := number.SerialNumber(); got != "123456" {
+		t.Errorf("SerialNumber() = %v, want %v", got, "123456")
+	}
+	if got := number.CheckDigit(); got != 0 {
+		t.Errorf("CheckDigit() = %v, want %v", got, 0)
+	}
+}
+
+func TestNumber_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    Number
+		wantErr bool
+	}{
+		{"Unmarshal container number", "CSQU3054383", newNum("CSQ", "U", "305438", 3), false},
+		{"Unmarshal container number with spaces", "CSQ U 305438 3", newNum("CSQ", "U", "305438", 3), false},
+		{"Unmarshal container number with check digit 10", "CMAU1639120", newNum("CMA", "U", "163912", 0), false},
+		{"Unmarshal returns error for wrong check digit", "CSQU3054384", Number{}, true},
+		{"Unmarshal returns error for lower case owner code", "csqU3054383", Number{}, true},
+		{"Unmarshal returns error for invalid equipment category ID", "CSQ13054383", Number{}, true},
+		{"Unmarshal returns error for invalid serial number", "CSQU30543X3", Number{}, true},
+		{"Unmarshal returns error for invalid check digit", "CSQU305438X", Number{}, true},
+		{"Unmarshal returns error for wrong length", "CSQU305438", Number{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Number
+			if err := got.UnmarshalText([]byte(tt.text)); (err != nil) != tt.wantErr {
+				t.Errorf("Number.UnmarshalText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Number.UnmarshalText() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIgnoringCheckDigit(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Number
+		wantErr bool
+	}{
+		{"Parse container number", "CSQU3054383", newNum("CSQ", "U", "305438", 3), false},
+		{"Parse container number with wrong check digit", "CSQ U 305438 4", newNum("CSQ", "U", "305438", 3), false},
+		{"Parse container number with invalid check digit", "CSQU305438X", newNum("CSQ", "U", "305438", 3), false},
+		{"Parse container number without check digit", "CSQU305438", newNum("CSQ", "U", "305438", 3), false},
+		{"Parse returns error for invalid serial number", "CSQU30543X3", Number{}, true},
+		{"Parse returns error for wrong length", "CSQU30543", Number{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIgnoringCheckDigit(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseIgnoringCheckDigit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseIgnoringCheckDigit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumber_JSON(t *testing.T) {
+	type container struct {
+		Number Number `json:"number"`
+	}
+
+	number := newNum("CSQ", "U", "305438", 3)
+	number.SetSeparators("-", "-", "-")
+
+	b, err := json.Marshal(container{number})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if got, want := string(b), `{"number":"CSQU3054383"}`; got != want {
+		t.Errorf("json.Marshal() = %v, want %v", got, want)
+	}
+
+	var got container
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if want := newNum("CSQ", "U", "305438", 3); !reflect.DeepEqual(got.Number, want) {
+		t.Errorf("json.Unmarshal() = %v, want %v", got.Number, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"number":"CSQU3054384"}`), &got); err == nil {
+		t.Errorf("json.Unmarshal() error = nil, want error for invalid check digit")
+	}
+}
+
+func TestNumber_ScanValue(t *testing.T) {
+	number := newNum("CSQ", "U", "305438", 3)
+
+	value, err := number.Value()
+	if err != nil {
+		t.Fatalf("Number.Value() error = %v", err)
+	}
+	if value != "CSQU3054383" {
+		t.Errorf("Number.Value() = %v, want %v", value, "CSQU3054383")
+	}
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		wantErr bool
+	}{
+		{"Scan string", "CSQU3054383", false},
+		{"Scan bytes", []byte("CSQU3054383"), false},
+		{"Scan returns error for int", 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Number
+			err := got.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Number.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, number) {
+				t.Errorf("Number.Scan() = %v, want %v", got, number)
+			}
+		})
+	}
+}
+
+func TestNumber_Zero(t *testing.T) {
+	type container struct {
+		Number Number `json:"number"`
+	}
+
+	b, err := json.Marshal(container{})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if got, want := string(b), `{"number":null}`; got != want {
+		t.Errorf("json.Marshal() = %v, want %v", got, want)
+	}
+	got := container{newNum("CSQ", "U", "305438", 3)}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !got.Number.IsZero() {
+		t.Errorf("json.Unmarshal() = %v, want zero value", got.Number)
+	}
+
+	text, err := Number{}.MarshalText()
+	if err != nil {
+		t.Fatalf("Number.MarshalText() error = %v", err)
+	}
+	number := newNum("CSQ", "U", "305438", 3)
+	if err := number.UnmarshalText(text); err != nil || !number.IsZero() {
+		t.Errorf("Number.UnmarshalText() = %v, %v, want zero value", number, err)
+	}
+
+	value, err := Number{}.Value()
+	if err != nil || value != nil {
+		t.Errorf("Number.Value() = %v, %v, want nil", value, err)
+	}
+	number = newNum("CSQ", "U", "305438", 3)
+	if err := number.Scan(value); err != nil || !number.IsZero() {
+		t.Errorf("Number.Scan() = %v, %v, want zero value", number, err)
+	}
+}
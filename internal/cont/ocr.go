@@ -0,0 +1,117 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ocrConfusions maps a character to characters that OCR commonly confuses it with.
+// More likely confusions come first.
+var ocrConfusions = map[byte]string{
+	'O': "0DQ",
+	'0': "ODQ",
+	'D': "0O",
+	'Q': "0O",
+	'I': "1L",
+	'1': "IL7",
+	'7': "1",
+	'L': "1I",
+	'B': "8",
+	'8': "B36",
+	'3': "8",
+	'S': "5",
+	'5': "S6",
+	'Z': "2",
+	'2': "Z",
+	'G': "6C",
+	'6': "G8",
+	'C': "G",
+}
+
+// maxOCRCorrections limits the corrections of characters that are already
+// letters in letter positions or digits in digit positions.
+const maxOCRCorrections = 2
+
+type ocrCandidate struct {
+	chars string
+	cost  int
+}
+
+// CorrectOCR corrects a container number with confused characters read by OCR.
+// Digits in the owner code and equipment category ID are replaced by letters and
+// letters in the serial number and check digit are replaced by digits. Further
+// commonly confused characters are substituted. Only container numbers with a
+// correct check digit are returned for which isOwnerCode and isEquipCatID return
+// true. The container numbers are ordered from most to least likely.
+func CorrectOCR(chars string, isOwnerCode func(code string) bool, isEquipCatID func(ID string) bool) []Number {
+	chars = strings.ToUpper(chars)
+	if len(chars) != 11 {
+		return nil
+	}
+
+	var candidates []ocrCandidate
+	var correct func(prefix string, cost, corrections int)
+	correct = func(prefix string, cost, corrections int) {
+		pos := len(prefix)
+		if pos == len(chars) {
+			if isValidOCRCandidate(prefix, isOwnerCode, isEquipCatID) {
+				candidates = append(candidates, ocrCandidate{prefix, cost})
+			}
+			return
+		}
+		char := chars[pos]
+		fits := isDigitPos(pos) && isDigits(string(char)) || !isDigitPos(pos) && isUpperLetter(string(char))
+		if fits {
+			correct(prefix+string(char), cost, corrections)
+			if corrections == maxOCRCorrections {
+				return
+			}
+			corrections++
+		}
+		for idx, confusion := range ocrConfusions[char] {
+			if isDigitPos(pos) == isDigits(string(confusion)) {
+				correct(prefix+string(confusion), cost+idx+1, corrections)
+			}
+		}
+	}
+	correct("", 0, 0)
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].cost != candidates[j].cost {
+			return candidates[i].cost < candidates[j].cost
+		}
+		return candidates[i].chars < candidates[j].chars
+	})
+
+	contNums := make([]Number, 0, len(candidates))
+	for _, candidate := range candidates {
+		checkDigit, _ := strconv.Atoi(candidate.chars[10:])
+		contNums = append(contNums, newNum(candidate.chars[0:3], candidate.chars[3:4], candidate.chars[4:10], checkDigit))
+	}
+	return contNums
+}
+
+func isDigitPos(pos int) bool {
+	return pos > 3
+}
+
+func isValidOCRCandidate(chars string, isOwnerCode func(code string) bool, isEquipCatID func(ID string) bool) bool {
+	checkDigit, _ := strconv.Atoi(chars[10:])
+	return CalcCheckDigit(chars[0:3], chars[3:4], chars[4:10])%10 == checkDigit &&
+		isOwnerCode(chars[0:3]) &&
+		isEquipCatID(chars[3:4])
+}
@@ -0,0 +1,92 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCorrectOCR(t *testing.T) {
+	isAny := func(string) bool { return true }
+	isOneOf := func(values ...string) func(string) bool {
+		return func(value string) bool {
+			for _, v := range values {
+				if v == value {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	tests := []struct {
+		name         string
+		chars        string
+		isOwnerCode  func(string) bool
+		isEquipCatID func(string) bool
+		want         []Number
+	}{
+		{
+			"Correct digit in owner code and letter in serial number",
+			"C5QU3O54383",
+			isOneOf("CSQ"),
+			isOneOf("U", "J", "Z"),
+			[]Number{newNum("CSQ", "U", "305438", 3)},
+		},
+		{
+			"Correct letters in serial number and check digit",
+			"ABCU1234S6O",
+			isOneOf("ABC"),
+			isOneOf("U", "J", "Z"),
+			[]Number{newNum("ABC", "U", "123456", 0)},
+		},
+		{
+			"Rank candidates of wrong check digit",
+			"CSQU3054388",
+			isAny,
+			isOneOf("U", "J", "Z"),
+			[]Number{
+				newNum("CSQ", "U", "305438", 3),
+				newNum("CSQ", "U", "805438", 6),
+				newNum("CSO", "U", "305438", 6),
+				newNum("CSQ", "U", "305433", 6),
+			},
+		},
+		{
+			"Filter unregistered owner codes",
+			"CSQU3054388",
+			isOneOf("CSQ"),
+			isOneOf("U", "J", "Z"),
+			[]Number{
+				newNum("CSQ", "U", "305438", 3),
+				newNum("CSQ", "U", "805438", 6),
+				newNum("CSQ", "U", "305433", 6),
+			},
+		},
+		{
+			"No candidates for too short input",
+			"CSQU305438",
+			isAny,
+			isAny,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CorrectOCR(tt.chars, tt.isOwnerCode, tt.isEquipCatID); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CorrectOCR() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
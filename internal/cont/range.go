@@ -0,0 +1,247 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Range is a range of serial numbers for an owner code and an equipment category ID.
+// From and to are included. If from is greater than to, the range wraps around
+// from serial number 999999 to serial number 0.
+type Range struct {
+	ownerCode  string
+	equipCatID string
+	from       int
+	to         int
+}
+
+// NewRange returns a new Range. An error is returned if the owner code,
+// the equipment category ID or the serial numbers are not valid.
+func NewRange(ownerCode string, equipCatID string, from int, to int) (Range, error) {
+	if err := IsOwnerCode(ownerCode); err != nil {
+		return Range{}, err
+	}
+	if err := IsEquipCatID(equipCatID); err != nil {
+		return Range{}, err
+	}
+	for _, serialNum := range []int{from, to} {
+		if serialNum < 0 || serialNum > 999999 {
+			return Range{}, fmt.Errorf("%d is not in range from 0 to 999999", serialNum)
+		}
+	}
+	return Range{ownerCode, equipCatID, from, to}, nil
+}
+
+// OwnerCode returns the owner code.
+func (r Range) OwnerCode() string {
+	return r.ownerCode
+}
+
+// EquipCatID returns the equipment category ID.
+func (r Range) EquipCatID() string {
+	return r.equipCatID
+}
+
+// From returns the first serial number.
+func (r Range) From() int {
+	return r.from
+}
+
+// To returns the last serial number.
+func (r Range) To() int {
+	return r.to
+}
+
+func (r Range) String() string {
+	return fmt.Sprintf("%s %s %06d-%06d", r.ownerCode, r.equipCatID, r.from, r.to)
+}
+
+// Len returns the count of serial numbers in the range.
+func (r Range) Len() int {
+	return rangeLen(r.from, r.to)
+}
+
+// Contains returns true if the container number is in the range. The check digit is ignored.
+func (r Range) Contains(cn Number) bool {
+	if cn.ownerCode != r.ownerCode || cn.equipCatID != r.equipCatID {
+		return false
+	}
+	serialNum, err := strconv.Atoi(cn.serialNumber)
+	return err == nil && r.ContainsSerialNum(serialNum)
+}
+
+// ContainsSerialNum returns true if the serial number is in the range.
+func (r Range) ContainsSerialNum(serialNum int) bool {
+	for _, s := range r.segments() {
+		if serialNum >= s.from && serialNum <= s.to {
+			return true
+		}
+	}
+	return false
+}
+
+// Iterator returns a new iterator over all container numbers of the range.
+func (r Range) Iterator() *RangeIterator {
+	return &RangeIterator{r: r, it: -1}
+}
+
+// Intersect returns the ranges with serial numbers that are in both ranges.
+// Nil is returned for ranges of different owners or equipment categories.
+func (r Range) Intersect(other Range) []Range {
+	if !r.sameOwnerAndEquipCat(other) {
+		return nil
+	}
+	var segments []segment
+	for _, a := range r.segments() {
+		for _, b := range other.segments() {
+			from, to := max(a.from, b.from), min(a.to, b.to)
+			if from <= to {
+				segments = append(segments, segment{from, to})
+			}
+		}
+	}
+	return r.toRanges(segments)
+}
+
+// Union returns the ranges with serial numbers that are in any of both ranges.
+// Both ranges are returned for ranges of different owners or equipment categories.
+func (r Range) Union(other Range) []Range {
+	if !r.sameOwnerAndEquipCat(other) {
+		return []Range{r, other}
+	}
+	return r.toRanges(append(r.segments(), other.segments()...))
+}
+
+// Subtract returns the ranges with serial numbers that are in the range but not in the other range.
+// The range is returned for ranges of different owners or equipment categories.
+func (r Range) Subtract(other Range) []Range {
+	if !r.sameOwnerAndEquipCat(other) {
+		return []Range{r}
+	}
+	segments := r.segments()
+	for _, b := range other.segments() {
+		var remaining []segment
+		for _, a := range segments {
+			if b.to < a.from || b.from > a.to {
+				remaining = append(remaining, a)
+				continue
+			}
+			if a.from < b.from {
+				remaining = append(remaining, segment{a.from, b.from - 1})
+			}
+			if a.to > b.to {
+				remaining = append(remaining, segment{b.to + 1, a.to})
+			}
+		}
+		segments = remaining
+	}
+	return r.toRanges(segments)
+}
+
+func (r Range) sameOwnerAndEquipCat(other Range) bool {
+	return r.ownerCode == other.ownerCode && r.equipCatID == other.equipCatID
+}
+
+type segment struct {
+	from int
+	to   int
+}
+
+// segments returns the range as one or two ranges that do not wrap around.
+func (r Range) segments() []segment {
+	if r.from <= r.to {
+		return []segment{{r.from, r.to}}
+	}
+	return []segment{{r.from, 999999}, {0, r.to}}
+}
+
+// toRanges merges overlapping and adjacent segments and joins segments that
+// touch serial number 999999 and 0 to one wrapping range.
+func (r Range) toRanges(segments []segment) []Range {
+	if len(segments) == 0 {
+		return nil
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].from < segments[j].from
+	})
+	merged := []segment{segments[0]}
+	for _, s := range segments[1:] {
+		last := &merged[len(merged)-1]
+		if s.from <= last.to+1 {
+			last.to = max(last.to, s.to)
+			continue
+		}
+		merged = append(merged, s)
+	}
+	if len(merged) > 1 && merged[0].from == 0 && merged[len(merged)-1].to == 999999 {
+		merged[0].from = merged[len(merged)-1].from
+		merged = merged[:len(merged)-1]
+	}
+	ranges := make([]Range, 0, len(merged))
+	for _, s := range merged {
+		ranges = append(ranges, Range{r.ownerCode, r.equipCatID, s.from, s.to})
+	}
+	return ranges
+}
+
+// RangeIterator iterates over container numbers of a range.
+// Use Range.Iterator to create one.
+type RangeIterator struct {
+	r       Range
+	it      int
+	contNum Number
+}
+
+// Next advances the iterator to the next container number, which will then be
+// available through the ContNum method. It returns false when the iteration
+// stops by reaching the end of the range.
+func (i *RangeIterator) Next() bool {
+	if i.it+1 >= i.r.Len() {
+		return false
+	}
+	i.it++
+	serialNum := fmt.Sprintf("%06d", (i.r.from+i.it)%1000000)
+	checkDigit := CalcCheckDigit(i.r.ownerCode, i.r.equipCatID, serialNum)
+	i.contNum = newNum(i.r.ownerCode, i.r.equipCatID, serialNum, checkDigit%10)
+	return true
+}
+
+// ContNum returns the current container number.
+func (i *RangeIterator) ContNum() Number {
+	return i.contNum
+}
+
+func rangeLen(from, to int) int {
+	if from <= to {
+		return to + 1 - from
+	}
+	return to + 1000000 + 1 - from
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
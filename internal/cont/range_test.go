@@ -0,0 +1,203 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestRange(t *testing.T, from, to int) Range {
+	r, err := NewRange("ABC", "U", from, to)
+	if err != nil {
+		t.Fatalf("NewRange() error = %v", err)
+	}
+	return r
+}
+
+func TestNewRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		ownerCode  string
+		equipCatID string
+		from       int
+		to         int
+		wantErr    bool
+	}{
+		{"Create range", "ABC", "U", 0, 999999, false},
+		{"Create wrapping range", "ABC", "U", 999990, 10, false},
+		{"Create range returns error for invalid owner code", "AB", "U", 0, 1, true},
+		{"Create range returns error for invalid equipment category ID", "ABC", "1", 0, 1, true},
+		{"Create range returns error for negative serial number", "ABC", "U", -1, 1, true},
+		{"Create range returns error for too large serial number", "ABC", "U", 0, 1000000, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRange(tt.ownerCode, tt.equipCatID, tt.from, tt.to); (err != nil) != tt.wantErr {
+				t.Errorf("NewRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRange_Len(t *testing.T) {
+	tests := []struct {
+		name string
+		from int
+		to   int
+		want int
+	}{
+		{"Length of single serial number", 5, 5, 1},
+		{"Length of range", 100, 199, 100},
+		{"Length of all serial numbers", 0, 999999, 1000000},
+		{"Length of wrapping range", 999997, 2, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newTestRange(t, tt.from, tt.to).Len(); got != tt.want {
+				t.Errorf("Range.Len() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_Contains(t *testing.T) {
+	r := newTestRange(t, 999998, 1)
+	tests := []struct {
+		name    string
+		contNum Number
+		want    bool
+	}{
+		{"Contains serial number before wrap", newNum("ABC", "U", "999999", 2), true},
+		{"Contains serial number after wrap", newNum("ABC", "U", "000001", 2), true},
+		{"Does not contain serial number outside", newNum("ABC", "U", "000002", 2), false},
+		{"Does not contain other owner", newNum("ABD", "U", "000001", 2), false},
+		{"Does not contain other equipment category", newNum("ABC", "J", "000001", 2), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Contains(tt.contNum); got != tt.want {
+				t.Errorf("Range.Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_Iterator(t *testing.T) {
+	it := newTestRange(t, 999999, 1).Iterator()
+	var got []Number
+	for it.Next() {
+		got = append(got, it.ContNum())
+	}
+	want := []Number{
+		newNum("ABC", "U", "999999", CalcCheckDigit("ABC", "U", "999999")%10),
+		newNum("ABC", "U", "000000", CalcCheckDigit("ABC", "U", "000000")%10),
+		newNum("ABC", "U", "000001", CalcCheckDigit("ABC", "U", "000001")%10),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RangeIterator = %v, want %v", got, want)
+	}
+}
+
+func TestRange_SetOperations(t *testing.T) {
+	type span struct {
+		from int
+		to   int
+	}
+	tests := []struct {
+		name          string
+		a             span
+		b             span
+		wantIntersect []span
+		wantUnion     []span
+		wantSubtract  []span
+	}{
+		{
+			"Overlapping ranges",
+			span{100, 200},
+			span{150, 300},
+			[]span{{150, 200}},
+			[]span{{100, 300}},
+			[]span{{100, 149}},
+		},
+		{
+			"Disjoint ranges",
+			span{100, 200},
+			span{300, 400},
+			nil,
+			[]span{{100, 200}, {300, 400}},
+			[]span{{100, 200}},
+		},
+		{
+			"Adjacent ranges",
+			span{100, 200},
+			span{201, 300},
+			nil,
+			[]span{{100, 300}},
+			[]span{{100, 200}},
+		},
+		{
+			"Range inside range",
+			span{100, 400},
+			span{200, 300},
+			[]span{{200, 300}},
+			[]span{{100, 400}},
+			[]span{{100, 199}, {301, 400}},
+		},
+		{
+			"Wrapping ranges",
+			span{999990, 10},
+			span{5, 999995},
+			[]span{{5, 10}, {999990, 999995}},
+			[]span{{0, 999999}},
+			[]span{{999996, 4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestRange(t, tt.a.from, tt.a.to)
+			b := newTestRange(t, tt.b.from, tt.b.to)
+			toRanges := func(spans []span) []Range {
+				var ranges []Range
+				for _, s := range spans {
+					ranges = append(ranges, newTestRange(t, s.from, s.to))
+				}
+				return ranges
+			}
+			if got, want := a.Intersect(b), toRanges(tt.wantIntersect); !reflect.DeepEqual(got, want) {
+				t.Errorf("Range.Intersect() = %v, want %v", got, want)
+			}
+			if got, want := a.Union(b), toRanges(tt.wantUnion); !reflect.DeepEqual(got, want) {
+				t.Errorf("Range.Union() = %v, want %v", got, want)
+			}
+			if got, want := a.Subtract(b), toRanges(tt.wantSubtract); !reflect.DeepEqual(got, want) {
+				t.Errorf("Range.Subtract() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRange_SetOperationsWithDifferentOwners(t *testing.T) {
+	a := newTestRange(t, 100, 200)
+	b, _ := NewRange("XYZ", "U", 100, 200)
+	if got := a.Intersect(b); got != nil {
+		t.Errorf("Range.Intersect() = %v, want nil", got)
+	}
+	if got := a.Union(b); !reflect.DeepEqual(got, []Range{a, b}) {
+		t.Errorf("Range.Union() = %v, want %v", got, []Range{a, b})
+	}
+	if got := a.Subtract(b); !reflect.DeepEqual(got, []Range{a}) {
+		t.Errorf("Range.Subtract() = %v, want %v", got, []Range{a})
+	}
+}
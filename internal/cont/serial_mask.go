@@ -0,0 +1,162 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SerialNumMask restricts the allowed digits at each of the 6 positions of a serial number.
+// Use ParseSerialNumMask for initialization.
+type SerialNumMask struct {
+	mask    string
+	allowed [6][10]bool
+}
+
+// ParseSerialNumMask parses a mask of 6 positions. A position is a fixed digit,
+// a wildcard '*' for any digit or a character class in brackets. A character class
+// contains digits and ranges of digits, e.g. [0-35] for 0, 1, 2, 3 and 5. A '^'
+// after the opening bracket negates the class, e.g. [^9] for any digit except 9.
+func ParseSerialNumMask(mask string) (SerialNumMask, error) {
+	m := SerialNumMask{mask: mask}
+	pos := 0
+	for i := 0; i < len(mask); i++ {
+		if pos == 6 {
+			return SerialNumMask{}, NewErrContValidate(fmt.Sprintf("%s has more than 6 positions", mask))
+		}
+		switch c := mask[i]; {
+		case c >= '0' && c <= '9':
+			m.allowed[pos][c-'0'] = true
+		case c == '*':
+			for d := range m.allowed[pos] {
+				m.allowed[pos][d] = true
+			}
+		case c == '[':
+			end := strings.IndexByte(mask[i:], ']')
+			if end == -1 {
+				return SerialNumMask{}, NewErrContValidate(fmt.Sprintf("%s has an unclosed character class", mask))
+			}
+			allowed, err := parseCharClass(mask[i+1 : i+end])
+			if err != nil {
+				return SerialNumMask{}, NewErrContValidate(fmt.Sprintf("%s has %s", mask, err))
+			}
+			m.allowed[pos] = allowed
+			i += end
+		default:
+			return SerialNumMask{}, NewErrContValidate(fmt.Sprintf("%s has invalid character %c", mask, c))
+		}
+		pos++
+	}
+	if pos != 6 {
+		return SerialNumMask{}, NewErrContValidate(fmt.Sprintf("%s has not 6 positions", mask))
+	}
+	if m.Count() == 0 {
+		return SerialNumMask{}, NewErrContValidate(fmt.Sprintf("%s matches no serial number", mask))
+	}
+	return m, nil
+}
+
+func parseCharClass(class string) ([10]bool, error) {
+	var allowed [10]bool
+	negate := strings.HasPrefix(class, "^")
+	if negate {
+		class = class[1:]
+	}
+	if class == "" {
+		return allowed, fmt.Errorf("an empty character class")
+	}
+	for i := 0; i < len(class); i++ {
+		from := class[i]
+		to := from
+		if i+2 < len(class) && class[i+1] == '-' {
+			to = class[i+2]
+			i += 2
+		}
+		if from < '0' || from > '9' || to < '0' || to > '9' || from > to {
+			return allowed, fmt.Errorf("an invalid character class [%s]", class)
+		}
+		for d := from; d <= to; d++ {
+			allowed[d-'0'] = true
+		}
+	}
+	if negate {
+		for d := range allowed {
+			allowed[d] = !allowed[d]
+		}
+	}
+	return allowed, nil
+}
+
+// String returns the mask.
+func (m SerialNumMask) String() string {
+	return m.mask
+}
+
+// Matches returns true if every digit of the serial number is allowed.
+func (m SerialNumMask) Matches(serialNum int) bool {
+	for pos := 5; pos >= 0; pos-- {
+		if !m.allowed[pos][serialNum%10] {
+			return false
+		}
+		serialNum /= 10
+	}
+	return true
+}
+
+// Count returns the count of serial numbers that match the mask.
+func (m SerialNumMask) Count() int {
+	count := 1
+	for _, allowed := range m.allowed {
+		n := 0
+		for _, ok := range allowed {
+			if ok {
+				n++
+			}
+		}
+		count *= n
+	}
+	return count
+}
+
+// countByRemainder returns the count of matching serial numbers for every remainder
+// of the weighted sum of serial number digits modulo 11 as used in the check digit
+// calculation.
+func (m SerialNumMask) countByRemainder() [11]int {
+	var counts [11]int
+	counts[0] = 1
+	// Serial number digits have the weights 2^4 to 2^9.
+	weight := 16
+	for _, allowed := range m.allowed {
+		var next [11]int
+		for remainder, count := range counts {
+			if count == 0 {
+				continue
+			}
+			for d, ok := range allowed {
+				if ok {
+					next[(remainder+d*weight)%11] += count
+				}
+			}
+		}
+		counts = next
+		weight *= 2
+	}
+	return counts
+}
+
+func anySerialNumMask() SerialNumMask {
+	m, _ := ParseSerialNumMask("******")
+	return m
+}
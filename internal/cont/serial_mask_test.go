@@ -0,0 +1,87 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseSerialNumMask(t *testing.T) {
+	tests := []struct {
+		name      string
+		mask      string
+		wantCount int
+		wantErr   bool
+	}{
+		{"Parse fixed digits and wildcards", "45****", 10000, false},
+		{"Parse negated character class", "[^9]*****", 900000, false},
+		{"Parse character class with range", "[0-35]12345", 5, false},
+		{"Parse fixed serial number", "123456", 1, false},
+		{"Error for too few positions", "45***", 0, true},
+		{"Error for too many positions", "45*****", 0, true},
+		{"Error for unclosed character class", "[0-3*****", 0, true},
+		{"Error for empty character class", "[]*****", 0, true},
+		{"Error for invalid range", "[5-3]*****", 0, true},
+		{"Error for letter", "A*****", 0, true},
+		{"Error for no matching serial number", "[^0-9]*****", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSerialNumMask(tt.mask)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSerialNumMask() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Count() != tt.wantCount {
+				t.Errorf("SerialNumMask.Count() = %v, want %v", got.Count(), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestSerialNumMask_Matches(t *testing.T) {
+	mask, _ := ParseSerialNumMask("[^9]4*[13]*0")
+	tests := []struct {
+		serialNum int
+		want      bool
+	}{
+		{40110, true},
+		{840390, true},
+		{940110, false},
+		{50110, false},
+		{40210, false},
+		{40111, false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%06d", tt.serialNum), func(t *testing.T) {
+			if got := mask.Matches(tt.serialNum); got != tt.want {
+				t.Errorf("SerialNumMask.Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSerialNumMask_countByRemainder(t *testing.T) {
+	mask, _ := ParseSerialNumMask("4[0-1]*[^5]**")
+	var want [11]int
+	for serialNum := 0; serialNum < 1000000; serialNum++ {
+		if mask.Matches(serialNum) {
+			want[CalcCheckDigit("", "", fmt.Sprintf("0000%06d", serialNum))]++
+		}
+	}
+	if got := mask.countByRemainder(); got != want {
+		t.Errorf("SerialNumMask.countByRemainder() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// SizeTypeGenerator generates pseudo random size and type codes.
+// Use NewSizeTypeGenerator for initialization.
+type SizeTypeGenerator struct {
+	rnd          *rand.Rand
+	lengths      *weightedCodes
+	heightWidths *weightedCodes
+	groups       *weightedCodes
+	typesOfGroup map[string][]string
+}
+
+// NewSizeTypeGenerator returns a generator that chooses length codes, height and
+// width codes and type groups by weight. A code with weight 3 is chosen 3 times as
+// often as a code with weight 1. A type code is chosen equally from type codes of
+// the chosen group. Every group of groups must have at least one type code.
+func NewSizeTypeGenerator(seed int64, lengths, heightWidths, groups map[string]int,
+	typeCodes []string) (*SizeTypeGenerator, error) {
+
+	typesOfGroup := map[string][]string{}
+	for _, typeCode := range typeCodes {
+		if err := IsTypeCode(typeCode); err != nil {
+			return nil, err
+		}
+		group := typeCode[:1]
+		typesOfGroup[group] = append(typesOfGroup[group], typeCode)
+	}
+	for group := range groups {
+		if len(typesOfGroup[group]) == 0 {
+			return nil, fmt.Errorf("type group %s has no type codes", group)
+		}
+	}
+
+	lengthCodes, err := newWeightedCodes("length codes", lengths, IsLengthCode)
+	if err != nil {
+		return nil, err
+	}
+	heightWidthCodes, err := newWeightedCodes("height and width codes", heightWidths, IsHeightWidthCode)
+	if err != nil {
+		return nil, err
+	}
+	groupCodes, err := newWeightedCodes("type groups", groups, func(string) error { return nil })
+	if err != nil {
+		return nil, err
+	}
+
+	return &SizeTypeGenerator{
+		rnd:          rand.New(rand.NewSource(seed)),
+		lengths:      lengthCodes,
+		heightWidths: heightWidthCodes,
+		groups:       groupCodes,
+		typesOfGroup: typesOfGroup,
+	}, nil
+}
+
+// Generate returns a pseudo random length code, height and width code and type code.
+func (g *SizeTypeGenerator) Generate() (lengthCode, heightWidthCode, typeCode string) {
+	lengthCode = g.lengths.choose(g.rnd)
+	heightWidthCode = g.heightWidths.choose(g.rnd)
+	types := g.typesOfGroup[g.groups.choose(g.rnd)]
+	typeCode = types[g.rnd.Intn(len(types))]
+	return lengthCode, heightWidthCode, typeCode
+}
+
+type weightedCodes struct {
+	codes      []string
+	cumWeights []int
+}
+
+func newWeightedCodes(name string, weights map[string]int, isCode func(string) error) (*weightedCodes, error) {
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("cannot generate without %s", name)
+	}
+	w := &weightedCodes{}
+	for code := range weights {
+		w.codes = append(w.codes, code)
+	}
+	// Sorting makes the choice independent of the map order.
+	sort.Strings(w.codes)
+	sum := 0
+	for _, code := range w.codes {
+		if err := isCode(code); err != nil {
+			return nil, err
+		}
+		if weights[code] < 1 {
+			return nil, fmt.Errorf("weight %d of %s is lower than 1", weights[code], code)
+		}
+		sum += weights[code]
+		w.cumWeights = append(w.cumWeights, sum)
+	}
+	return w, nil
+}
+
+func (w *weightedCodes) choose(rnd *rand.Rand) string {
+	r := rnd.Intn(w.cumWeights[len(w.cumWeights)-1])
+	return w.codes[sort.Search(len(w.cumWeights), func(i int) bool { return w.cumWeights[i] > r })]
+}
@@ -0,0 +1,89 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"testing"
+)
+
+func TestSizeTypeGenerator(t *testing.T) {
+	g, err := NewSizeTypeGenerator(1,
+		map[string]int{"2": 1, "4": 3},
+		map[string]int{"5": 1},
+		map[string]int{"G": 1},
+		[]string{"G1", "G2", "R1"})
+	if err != nil {
+		t.Fatalf("NewSizeTypeGenerator() error = %v", err)
+	}
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		lengthCode, heightWidthCode, typeCode := g.Generate()
+		if heightWidthCode != "5" {
+			t.Fatalf("SizeTypeGenerator.Generate() height and width code = %v, want 5", heightWidthCode)
+		}
+		if typeCode != "G1" && typeCode != "G2" {
+			t.Fatalf("SizeTypeGenerator.Generate() type code = %v, want G1 or G2", typeCode)
+		}
+		counts[lengthCode]++
+	}
+	if counts["4"] < 700 || counts["4"] > 800 {
+		t.Errorf("SizeTypeGenerator.Generate() generated %d of 1000 length codes with weight 3 of 4", counts["4"])
+	}
+}
+
+func TestNewSizeTypeGenerator(t *testing.T) {
+	tests := []struct {
+		name         string
+		lengths      map[string]int
+		heightWidths map[string]int
+		groups       map[string]int
+		typeCodes    []string
+	}{
+		{
+			"Error for group without type codes",
+			map[string]int{"2": 1},
+			map[string]int{"2": 1},
+			map[string]int{"R": 1},
+			[]string{"G1"},
+		},
+		{
+			"Error for no length codes",
+			nil,
+			map[string]int{"2": 1},
+			map[string]int{"G": 1},
+			[]string{"G1"},
+		},
+		{
+			"Error for invalid height and width code",
+			map[string]int{"2": 1},
+			map[string]int{"22": 1},
+			map[string]int{"G": 1},
+			[]string{"G1"},
+		},
+		{
+			"Error for weight lower than 1",
+			map[string]int{"2": 0},
+			map[string]int{"2": 1},
+			map[string]int{"G": 1},
+			[]string{"G1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSizeTypeGenerator(1, tt.lengths, tt.heightWidths, tt.groups, tt.typeCodes); err == nil {
+				t.Error("NewSizeTypeGenerator() returned no error")
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"fmt"
+)
+
+// CheckSubstitution checks for possible substitution errors.
+// Every character of owner code, equipment category ID, serial number and
+// check digit is substituted by every other valid character. Container numbers
+// with a correct check digit are returned. Substituted owner codes and equipment
+// category IDs are only used if isOwnerCode and isEquipCatID return true for them.
+// Substitutions of the other characters are only used if isOwnerCode returns true
+// for the owner code.
+func CheckSubstitution(ownerCode string, equipCatID string, serialNum string, checkDigit int,
+	isOwnerCode func(code string) bool, isEquipCatID func(ID string) bool) []Number {
+	contNums := make([]Number, 0)
+
+	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	const digits = "0123456789"
+
+	appendIfValid := func(ownerCode, equipCatID, serialNum string) {
+		calcCheckDigit := CalcCheckDigit(ownerCode, equipCatID, serialNum) % 10
+		if calcCheckDigit == checkDigit {
+			contNums = append(contNums, newNum(ownerCode, equipCatID, serialNum, calcCheckDigit))
+		}
+	}
+
+	for pos := range ownerCode {
+		for _, letter := range letters {
+			if byte(letter) == ownerCode[pos] {
+				continue
+			}
+			substituted := fmt.Sprintf("%s%c%s", ownerCode[:pos], letter, ownerCode[pos+1:])
+			if isOwnerCode(substituted) {
+				appendIfValid(substituted, equipCatID, serialNum)
+			}
+		}
+	}
+
+	if !isOwnerCode(ownerCode) {
+		return contNums
+	}
+
+	for _, letter := range letters {
+		if string(letter) != equipCatID && isEquipCatID(string(letter)) {
+			appendIfValid(ownerCode, string(letter), serialNum)
+		}
+	}
+
+	for pos := range serialNum {
+		for _, digit := range digits {
+			if byte(digit) == serialNum[pos] {
+				continue
+			}
+			appendIfValid(ownerCode, equipCatID, fmt.Sprintf("%s%c%s", serialNum[:pos], digit, serialNum[pos+1:]))
+		}
+	}
+
+	if calcCheckDigit := CalcCheckDigit(ownerCode, equipCatID, serialNum) % 10; calcCheckDigit != checkDigit {
+		contNums = append(contNums, newNum(ownerCode, equipCatID, serialNum, calcCheckDigit))
+	}
+	return contNums
+}
@@ -0,0 +1,91 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cont
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckSubstitution(t *testing.T) {
+	isAny := func(string) bool { return true }
+	isOneOf := func(values ...string) func(string) bool {
+		return func(value string) bool {
+			for _, v := range values {
+				if v == value {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	type args struct {
+		ownerCode    string
+		equipCatID   string
+		serialNum    string
+		checkDigit   int
+		isOwnerCode  func(string) bool
+		isEquipCatID func(string) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want []Number
+	}{
+		{
+			name: "Test ABC U 123456 (0)1 with registered owner ABC",
+			args: args{"ABC", "U", "123456", 1, isOneOf("ABC"), isOneOf("U", "J", "Z")},
+			want: []Number{
+				newNum("ABC", "U", "113456", 1),
+				newNum("ABC", "U", "128456", 1),
+				newNum("ABC", "U", "123156", 1),
+				newNum("ABC", "U", "123496", 1),
+				newNum("ABC", "U", "123458", 1),
+				newNum("ABC", "U", "123456", 0),
+			},
+		},
+		{
+			name: "Test ABC U 123456 (0)1 with all owners",
+			args: args{"ABC", "U", "123456", 1, isAny, isOneOf("U", "J", "Z")},
+			want: []Number{
+				newNum("AHC", "U", "123456", 1),
+				newNum("ARC", "U", "123456", 1),
+				newNum("ABF", "U", "123456", 1),
+				newNum("ABP", "U", "123456", 1),
+				newNum("ABZ", "U", "123456", 1),
+				newNum("ABC", "U", "113456", 1),
+				newNum("ABC", "U", "128456", 1),
+				newNum("ABC", "U", "123156", 1),
+				newNum("ABC", "U", "123496", 1),
+				newNum("ABC", "U", "123458", 1),
+				newNum("ABC", "U", "123456", 0),
+			},
+		},
+		{
+			name: "Test ABD U 123456 0 with unregistered owner ABD",
+			args: args{"ABD", "U", "123456", 0, isOneOf("ABC"), isOneOf("U", "J", "Z")},
+			want: []Number{
+				newNum("ABC", "U", "123456", 0),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckSubstitution(tt.args.ownerCode, tt.args.equipCatID, tt.args.serialNum, tt.args.checkDigit,
+				tt.args.isOwnerCode, tt.args.isEquipCatID); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckSubstitution() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
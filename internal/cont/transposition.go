@@ -14,7 +14,6 @@
 package cont
 
 import (
-	"fmt"
 	"strconv"
 )
 
@@ -26,8 +25,9 @@ func CheckTransposition(ownerCode string, equipCatID string, serialNum string) [
 	checkDigit := CalcCheckDigit(ownerCode, equipCatID, serialNum) % 10
 	for pos := range serialNum {
 		if pos < 5 && serialNum[pos] != serialNum[pos+1] {
-			transposedSerialNum := fmt.Sprintf(
-				"%s%c%c%s", serialNum[:pos], serialNum[pos+1], serialNum[pos], serialNum[pos+2:])
+			transposed := []byte(serialNum)
+			transposed[pos], transposed[pos+1] = transposed[pos+1], transposed[pos]
+			transposedSerialNum := string(transposed)
 			calcCheckDigit := CalcCheckDigit(ownerCode, equipCatID, transposedSerialNum) % 10
 			if checkDigit == calcCheckDigit {
 				contNums = append(contNums, newNum(ownerCode, equipCatID, transposedSerialNum, calcCheckDigit))
@@ -60,3 +60,12 @@ func isUpperLetter(s string) bool {
 	}
 	return true
 }
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -45,19 +45,40 @@ type EquipCatDecoder interface {
 // LengthDecoder decodes a code to a length.
 type LengthDecoder interface {
 	Decode(code string) (bool, cont.Length)
+
+	AllLengthCodes() []string
 }
 
 // HeightWidthDecoder decodes a code to height and width.
 type HeightWidthDecoder interface {
 	Decode(code string) (bool, cont.HeightWidth)
+
+	AllHeightWidthCodes() []string
 }
 
 // TypeDecoder decodes a code to type and group.
 type TypeDecoder interface {
 	Decode(code string) (bool, cont.TypeAndGroup)
+
+	AllTypeCodes() []string
 }
 
 // TimestampUpdater updates a timestamp with an implemented time.
 type TimestampUpdater interface {
 	Update() error
 }
+
+// Ledger records allocated container numbers of an implemented source.
+type Ledger interface {
+	// Allocate locks the ledger against concurrent allocations, passes all
+	// recorded allocations to allocate and records the returned allocations.
+	// Nothing is recorded if allocate returns an error.
+	Allocate(allocate func(allocations []cont.Allocation) ([]cont.Allocation, error)) error
+
+	// List returns all recorded allocations.
+	List() ([]cont.Allocation, error)
+
+	// Release removes the allocations of the container numbers and returns the
+	// container numbers that are not allocated.
+	Release(contNums []cont.Number) ([]cont.Number, error)
+}
@@ -0,0 +1,284 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/meyermarcel/icm/internal/cont"
+	"github.com/meyermarcel/icm/internal/data"
+)
+
+const (
+	ledgerFileName     = "ledger.json"
+	ledgerLockFileName = ledgerFileName + ".lock"
+	ledgerLockTimeout  = 10 * time.Second
+	ledgerLockRetry    = 50 * time.Millisecond
+)
+
+type allocation struct {
+	ContNum cont.Number `json:"container-number"`
+	Time    time.Time   `json:"allocated"`
+	Note    string      `json:"note,omitempty"`
+}
+
+type ledger struct {
+	path        string
+	lockTimeout time.Duration
+}
+
+// NewLedger returns a ledger that uses a file in path as a data source.
+// The file is created with the first allocation.
+func NewLedger(path string) data.Ledger {
+	return &ledger{path: path, lockTimeout: ledgerLockTimeout}
+}
+
+// Allocate locks the ledger file, passes all recorded allocations to allocate and
+// writes the recorded and returned allocations atomically to the ledger file.
+func (l *ledger) Allocate(allocate func(allocations []cont.Allocation) ([]cont.Allocation, error)) error {
+	unlock, err := l.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	allocations, err := l.read()
+	if err != nil {
+		return err
+	}
+	newAllocations, err := allocate(allocations)
+	if err != nil {
+		return err
+	}
+	allocated := make(map[string]cont.Allocation, len(allocations))
+	for _, a := range allocations {
+		allocated[allocationKey(a.ContNum)] = a
+	}
+	for _, newAllocation := range newAllocations {
+		if a, ok := allocated[allocationKey(newAllocation.ContNum)]; ok {
+			return fmt.Errorf("%s is already allocated since %s",
+				newAllocation.ContNum, a.Time.Format(dateFormat))
+		}
+		allocated[allocationKey(newAllocation.ContNum)] = newAllocation
+	}
+	return l.write(append(allocations, newAllocations...))
+}
+
+// List returns all recorded allocations sorted by container number.
+func (l *ledger) List() ([]cont.Allocation, error) {
+	return l.read()
+}
+
+// Release removes the allocations of the container numbers from the ledger file
+// and returns the container numbers that are not allocated.
+func (l *ledger) Release(contNums []cont.Number) ([]cont.Number, error) {
+	unlock, err := l.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	allocations, err := l.read()
+	if err != nil {
+		return nil, err
+	}
+	release := make(map[string]bool, len(contNums))
+	for _, contNum := range contNums {
+		release[allocationKey(contNum)] = true
+	}
+	var kept []cont.Allocation
+	for _, a := range allocations {
+		if release[allocationKey(a.ContNum)] {
+			delete(release, allocationKey(a.ContNum))
+			continue
+		}
+		kept = append(kept, a)
+	}
+	var notAllocated []cont.Number
+	for _, contNum := range contNums {
+		if release[allocationKey(contNum)] {
+			notAllocated = append(notAllocated, contNum)
+		}
+	}
+	if len(kept) == len(allocations) {
+		return notAllocated, nil
+	}
+	return notAllocated, l.write(kept)
+}
+
+// lock creates a lock file exclusively and returns a function that removes it.
+// The lock file contains the process ID and the host name. A lock file of a
+// process of this host that is not running anymore is removed.
+func (l *ledger) lock() (func(), error) {
+	lockPath := filepath.Join(l.path, ledgerLockFileName)
+	deadline := time.Now().Add(l.lockTimeout)
+	owner := strconv.Itoa(os.Getpid())
+	if hostname, err := os.Hostname(); err == nil {
+		owner += " " + hostname
+	}
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, errWrite := f.WriteString(owner + "\n")
+			if errClose := f.Close(); errWrite == nil {
+				errWrite = errClose
+			}
+			if errWrite != nil {
+				_ = os.Remove(lockPath)
+				return nil, errWrite
+			}
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if removeStaleLock(lockPath) {
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("ledger is locked by another process, remove %s if no other process is running",
+				lockPath)
+		}
+		time.Sleep(ledgerLockRetry)
+	}
+}
+
+// removeStaleLock removes the lock file if its process is not running anymore
+// and returns true if it is removed.
+func removeStaleLock(lockPath string) bool {
+	b, err := ioutil.ReadFile(lockPath)
+	if err != nil || !isStaleLock(string(b)) {
+		return false
+	}
+	// The lock file is only removed if no other process replaced it in the meantime.
+	if current, err := ioutil.ReadFile(lockPath); err != nil || string(current) != string(b) {
+		return false
+	}
+	return os.Remove(lockPath) == nil
+}
+
+// isStaleLock returns true if the content of a lock file names a process of
+// this host that is not running anymore. Processes of other hosts are unknown.
+func isStaleLock(content string) bool {
+	fields := strings.Fields(content)
+	if len(fields) != 2 {
+		return false
+	}
+	if hostname, err := os.Hostname(); err != nil || fields[1] != hostname {
+		return false
+	}
+	pid, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return false
+	}
+	return !isRunning(pid)
+}
+
+// isRunning returns false if the process does not exist.
+func isRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return !errors.Is(process.Signal(syscall.Signal(0)), os.ErrProcessDone)
+}
+
+func (l *ledger) read() ([]cont.Allocation, error) {
+	b, err := ioutil.ReadFile(filepath.Join(l.path, ledgerFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	owners := map[string][]allocation{}
+	if err := json.Unmarshal(b, &owners); err != nil {
+		return nil, err
+	}
+	var allocations []cont.Allocation
+	for ownerCode, ownerAllocations := range owners {
+		for _, a := range ownerAllocations {
+			if a.ContNum.OwnerCode() != ownerCode {
+				return nil, fmt.Errorf("%s is not allocated for owner %s", a.ContNum, ownerCode)
+			}
+			allocations = append(allocations, cont.Allocation{ContNum: a.ContNum, Time: a.Time, Note: a.Note})
+		}
+	}
+	sortAllocations(allocations)
+	return allocations, nil
+}
+
+// write writes the allocations grouped by owner code to a temporary file and
+// renames it to the ledger file. A reader never sees a partially written file.
+func (l *ledger) write(allocations []cont.Allocation) error {
+	sortAllocations(allocations)
+	owners := map[string][]allocation{}
+	for _, a := range allocations {
+		ownerCode := a.ContNum.OwnerCode()
+		owners[ownerCode] = append(owners[ownerCode], allocation{ContNum: a.ContNum, Time: a.Time, Note: a.Note})
+	}
+	b, err := marshalNoHTMLEsc(owners)
+	if err != nil {
+		return err
+	}
+	ledgerPath := filepath.Join(l.path, ledgerFileName)
+	// A temporary file is only readable by its user, so the mode of the ledger
+	// file is kept.
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(ledgerPath); err == nil {
+		mode = info.Mode().Perm()
+	}
+	tmp, err := ioutil.TempFile(l.path, ledgerFileName+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), ledgerPath)
+}
+
+func sortAllocations(allocations []cont.Allocation) {
+	sort.Slice(allocations, func(i, j int) bool {
+		return allocations[i].ContNum.String() < allocations[j].ContNum.String()
+	})
+}
+
+// allocationKey identifies an allocation by owner code, equipment category ID and serial number.
+func allocationKey(contNum cont.Number) string {
+	return contNum.OwnerCode() + contNum.EquipCatID() + contNum.SerialNumber()
+}
@@ -0,0 +1,203 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meyermarcel/icm/internal/cont"
+)
+
+func newTestAllocations(t *testing.T, contNums ...string) []cont.Allocation {
+	var allocations []cont.Allocation
+	for _, s := range contNums {
+		var contNum cont.Number
+		if err := contNum.UnmarshalText([]byte(s)); err != nil {
+			t.Fatal(err)
+		}
+		allocations = append(allocations, cont.Allocation{
+			ContNum: contNum,
+			Time:    time.Date(2018, 10, 29, 15, 0, 0, 0, time.UTC),
+			Note:    "some-note",
+		})
+	}
+	return allocations
+}
+
+func TestLedger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ledger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	l := NewLedger(dir)
+
+	allocations, err := l.List()
+	if err != nil || allocations != nil {
+		t.Fatalf("List() of missing ledger = %v, %v, want nil, nil", allocations, err)
+	}
+
+	first := newTestAllocations(t, "DEFU0000022", "ABCU0000017")
+	if err := l.Allocate(func(allocations []cont.Allocation) ([]cont.Allocation, error) {
+		return first, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	err = l.Allocate(func(allocations []cont.Allocation) ([]cont.Allocation, error) {
+		if !reflect.DeepEqual(allocations, newTestAllocations(t, "ABCU0000017", "DEFU0000022")) {
+			t.Errorf("Allocate() passed %v", allocations)
+		}
+		return newTestAllocations(t, "ABCU0000017"), nil
+	})
+	if err == nil {
+		t.Error("Allocate() of allocated container number returned no error")
+	}
+
+	notAllocated, err := l.Release(newTestAllocationNums(t, "ABCU0000017", "ABCU0000022"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := newTestAllocationNums(t, "ABCU0000022"); !reflect.DeepEqual(notAllocated, want) {
+		t.Errorf("Release() = %v, want %v", notAllocated, want)
+	}
+
+	allocations, err = l.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := newTestAllocations(t, "DEFU0000022"); !reflect.DeepEqual(allocations, want) {
+		t.Errorf("List() = %v, want %v", allocations, want)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ledgerLockFileName)); !os.IsNotExist(err) {
+		t.Errorf("lock file exists after release, %v", err)
+	}
+}
+
+func TestLedger_lock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ledger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, ledgerLockFileName), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	l := &ledger{path: dir, lockTimeout: 0}
+	err = l.Allocate(func(allocations []cont.Allocation) ([]cont.Allocation, error) {
+		t.Error("Allocate() called allocate of locked ledger")
+		return nil, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), filepath.Join(dir, ledgerLockFileName)) {
+		t.Errorf("Allocate() of locked ledger error = %v, want error with lock file path", err)
+	}
+}
+
+func TestLedger_staleLock(t *testing.T) {
+	// The process ID of a finished process is not running anymore.
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		content    string
+		wantLocked bool
+	}{
+		{"Remove lock of finished process", fmt.Sprintf("%d %s\n", cmd.Process.Pid, hostname), false},
+		{"Keep lock of running process", fmt.Sprintf("%d %s\n", os.Getpid(), hostname), true},
+		{"Keep lock of other host", fmt.Sprintf("%d other-%s\n", cmd.Process.Pid, hostname), true},
+		{"Keep lock without host", fmt.Sprintf("%d\n", cmd.Process.Pid), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "ledger")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			if err := ioutil.WriteFile(filepath.Join(dir, ledgerLockFileName), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			l := &ledger{path: dir, lockTimeout: 0}
+			err = l.Allocate(func(allocations []cont.Allocation) ([]cont.Allocation, error) {
+				return nil, nil
+			})
+			if (err != nil) != tt.wantLocked {
+				t.Errorf("Allocate() error = %v, want locked %v", err, tt.wantLocked)
+			}
+		})
+	}
+}
+
+func TestLedger_fileMode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ledger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	l := NewLedger(dir)
+	allocate := func(contNum string) {
+		err := l.Allocate(func(allocations []cont.Allocation) ([]cont.Allocation, error) {
+			return newTestAllocations(t, contNum), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	mode := func() os.FileMode {
+		info, err := os.Stat(filepath.Join(dir, ledgerFileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info.Mode().Perm()
+	}
+
+	allocate("ABCU1234560")
+	if got := mode(); got != 0644 {
+		t.Errorf("mode of created ledger file = %v, want %v", got, os.FileMode(0644))
+	}
+	if err := os.Chmod(filepath.Join(dir, ledgerFileName), 0664); err != nil {
+		t.Fatal(err)
+	}
+	allocate("ABCU1234576")
+	if got := mode(); got != 0664 {
+		t.Errorf("mode of written ledger file = %v, want %v", got, os.FileMode(0664))
+	}
+}
+
+func newTestAllocationNums(t *testing.T, contNums ...string) []cont.Number {
+	var nums []cont.Number
+	for _, a := range newTestAllocations(t, contNums...) {
+		nums = append(nums, a.ContNum)
+	}
+	return nums
+}
@@ -16,6 +16,7 @@ package file
 import (
 	"encoding/json"
 	"path/filepath"
+	"sort"
 
 	"github.com/meyermarcel/icm/internal/data"
 
@@ -77,6 +78,16 @@ func (l *lengthDecoder) Decode(code string) (bool, cont.Length) {
 	return false, cont.Length{}
 }
 
+// AllLengthCodes returns all sorted length codes.
+func (l *lengthDecoder) AllLengthCodes() []string {
+	var codes []string
+	for code := range l.lengths {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
 type heightWidthDecoder struct {
 	heightWidths map[string]heightWidth
 }
@@ -89,6 +100,16 @@ func (hw *heightWidthDecoder) Decode(code string) (bool, cont.HeightWidth) {
 	return false, cont.HeightWidth{}
 }
 
+// AllHeightWidthCodes returns all sorted height and width codes.
+func (hw *heightWidthDecoder) AllHeightWidthCodes() []string {
+	var codes []string
+	for code := range hw.heightWidths {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
 const lengthHeightWidthJSON = `{
   "length": {
     "1": "2991 mm",
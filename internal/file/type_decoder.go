@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"path/filepath"
+	"sort"
 
 	"github.com/meyermarcel/icm/internal/data"
 
@@ -87,6 +88,18 @@ func (tg *typeAndGroupDecoder) Decode(code string) (bool, cont.TypeAndGroup) {
 	return true, typeAndGroup
 }
 
+// AllTypeCodes returns all sorted type codes with a known group.
+func (tg *typeAndGroupDecoder) AllTypeCodes() []string {
+	var codes []string
+	for code := range tg.types {
+		if _, exists := tg.groups[string(code[0])]; exists {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
 const typeJSON = `{
   "G0": "General - Openings at one or both ends",
   "G1": "General - Passive vents at upper part of cargo space",
@@ -0,0 +1,223 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package input
+
+import (
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+)
+
+type htmlPart struct {
+	Value     string
+	Valid     bool
+	Error     string
+	Separator string
+}
+
+type htmlRow struct {
+	Line     int
+	Input    string
+	Valid    bool
+	Parts    []htmlPart
+	Owner    string
+	Category string
+	SizeType string
+	Errors   []string
+}
+
+type htmlErrorCount struct {
+	Part  string
+	Count int
+}
+
+type htmlReport struct {
+	Total   int
+	Valid   int
+	Invalid int
+	Errors  []htmlErrorCount
+	Rows    []htmlRow
+}
+
+// HTMLPrinter prints the inputs of all lines as a self-contained HTML report
+// with a summary and a sortable table. Rows are buffered and printed on Close.
+// Use NewHTMLPrinter to instantiate one.
+type HTMLPrinter struct {
+	writer         io.Writer
+	separators     []string
+	separatorsFunc func(inputs []Input)
+	report         htmlReport
+	errorCounts    map[string]int
+}
+
+// NewHTMLPrinter creates a HTMLPrinter.
+func NewHTMLPrinter(writer io.Writer) *HTMLPrinter {
+	return &HTMLPrinter{
+		writer:      writer,
+		errorCounts: map[string]int{},
+	}
+}
+
+// SetSeparators sets the separators between inputs. Default separator is ' '.
+func (hp *HTMLPrinter) SetSeparators(separators ...string) {
+	hp.separators = separators
+}
+
+// SetSeparatorsFunc sets a function that can set the separators depending on inputs.
+func (hp *HTMLPrinter) SetSeparatorsFunc(separatorsFunc func(inputs []Input)) {
+	hp.separatorsFunc = separatorsFunc
+}
+
+// Print buffers the inputs as row of the report.
+func (hp *HTMLPrinter) Print(inputs []Input) error {
+	if hp.separatorsFunc != nil {
+		hp.separatorsFunc(inputs)
+	}
+	row := htmlRow{Line: hp.report.Total + 1, Valid: true}
+	data := map[string]string{}
+	for idx, input := range inputs {
+		row.Input = input.line
+		part := htmlPart{Value: input.value, Valid: input.err == nil}
+		if !input.isValidFmt() && input.err != nil {
+			part.Value = strings.Repeat("_", input.runeCount)
+		}
+		if input.err != nil {
+			part.Error = stripANSI(input.err.Error())
+			row.Valid = false
+			row.Errors = append(row.Errors, part.Error)
+			if len(input.data) > 0 {
+				hp.errorCounts[input.data[0].header]++
+			}
+		}
+		switch {
+		case idx == len(inputs)-1:
+		case idx < len(hp.separators):
+			part.Separator = hp.separators[idx]
+		default:
+			part.Separator = " "
+		}
+		row.Parts = append(row.Parts, part)
+		for _, datum := range input.data {
+			data[datum.header] = datum.value
+		}
+	}
+	row.Owner = joinNonEmpty(data["company"], data["city"], data["country"])
+	row.Category = data["equipment-category"]
+	row.SizeType = joinNonEmpty(data["length-description"], data["height-description"],
+		data["width-description"], data["type-description"])
+
+	hp.report.Total++
+	if row.Valid {
+		hp.report.Valid++
+	} else {
+		hp.report.Invalid++
+	}
+	hp.report.Rows = append(hp.report.Rows, row)
+	return nil
+}
+
+// Close writes the HTML report of all rows to writer.
+func (hp *HTMLPrinter) Close() error {
+	hp.report.Errors = nil
+	for part, count := range hp.errorCounts {
+		hp.report.Errors = append(hp.report.Errors, htmlErrorCount{Part: part, Count: count})
+	}
+	sort.Slice(hp.report.Errors, func(i, j int) bool {
+		if hp.report.Errors[i].Count != hp.report.Errors[j].Count {
+			return hp.report.Errors[i].Count > hp.report.Errors[j].Count
+		}
+		return hp.report.Errors[i].Part < hp.report.Errors[j].Part
+	})
+	return htmlReportTemplate.Execute(hp.writer, hp.report)
+}
+
+func joinNonEmpty(values ...string) string {
+	var nonEmpty []string
+	for _, value := range values {
+		if value != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+	return strings.Join(nonEmpty, ", ")
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Validation report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.5em; }
+.summary td { padding: 0.2em 1.5em 0.2em 0; }
+table.report { border-collapse: collapse; margin-top: 1.5em; }
+table.report th, table.report td { border-bottom: 1px solid #ddd; padding: 0.3em 0.8em; text-align: left; vertical-align: top; }
+table.report th { cursor: pointer; background: #f4f4f4; user-select: none; }
+table.report th::after { content: " \2195"; color: #aaa; }
+.marking { font-family: monospace; white-space: pre; }
+.valid { color: #1a7f37; }
+.invalid { color: #cf222e; font-weight: bold; }
+.status { text-align: center; }
+</style>
+</head>
+<body>
+<h1>Validation report</h1>
+<table class="summary">
+<tr><td>Lines</td><td>{{.Total}}</td></tr>
+<tr><td class="valid">Valid</td><td>{{.Valid}}</td></tr>
+<tr><td class="invalid">Invalid</td><td>{{.Invalid}}</td></tr>
+{{- range .Errors}}
+<tr><td>Errors of {{.Part}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+<table class="report" id="report">
+<thead>
+<tr><th data-type="number">Line</th><th>Input</th><th>Marking</th><th>Valid</th><th>Owner</th><th>Equipment category</th><th>Size and type</th><th>Errors</th></tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr>
+<td>{{.Line}}</td>
+<td class="marking">{{.Input}}</td>
+<td class="marking">{{range .Parts}}<span class="{{if .Valid}}valid{{else}}invalid{{end}}"{{if .Error}} title="{{.Error}}"{{end}}>{{.Value}}</span>{{.Separator}}{{end}}</td>
+<td class="status {{if .Valid}}valid{{else}}invalid{{end}}">{{if .Valid}}✔{{else}}✘{{end}}</td>
+<td>{{.Owner}}</td>
+<td>{{.Category}}</td>
+<td>{{.SizeType}}</td>
+<td>{{range $idx, $err := .Errors}}{{if $idx}}<br>{{end}}{{$err}}{{end}}</td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+<script>
+document.querySelectorAll("#report th").forEach(function (th, column) {
+  var ascending = true;
+  th.addEventListener("click", function () {
+    var tbody = document.querySelector("#report tbody");
+    var rows = Array.prototype.slice.call(tbody.rows);
+    var numeric = th.dataset.type === "number";
+    rows.sort(function (a, b) {
+      var x = a.cells[column].textContent, y = b.cells[column].textContent;
+      var order = numeric ? x - y : x.localeCompare(y);
+      return ascending ? order : -order;
+    });
+    ascending = !ascending;
+    rows.forEach(function (row) { tbody.appendChild(row); });
+  });
+});
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,91 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package input
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func newHTMLTestLines() [][]Input {
+	lines := newTableTestLines()
+	lines[1][0].data = []Datum{{header: "owner-code", value: "DEF"}}
+	return lines
+}
+
+func TestHTMLPrinter(t *testing.T) {
+	tests := []struct {
+		name         string
+		lines        [][]Input
+		wantContains []string
+	}{
+		{
+			"Print report",
+			newHTMLTestLines(),
+			[]string{
+				`<tr><td>Lines</td><td>2</td></tr>`,
+				`<tr><td class="valid">Valid</td><td>1</td></tr>`,
+				`<tr><td class="invalid">Invalid</td><td>1</td></tr>`,
+				`<tr><td>Errors of owner-code</td><td>1</td></tr>`,
+				`<span class="valid">ABC</span>-<span class="valid">U</span> `,
+				`<span class="invalid" title="DEF is not registered">DEF</span>-`,
+				`<span class="invalid" title="serial number is not 6 numbers long">______</span> `,
+				`<td>some-company</td>`,
+				`<td>some-length, some-height, some-type</td>`,
+				`<td>DEF is not registered<br>serial number is not 6 numbers long<br>check digit is not calculable</td>`,
+			},
+		},
+		{
+			"Print report without lines",
+			nil,
+			[]string{
+				`<tr><td>Lines</td><td>0</td></tr>`,
+				"<tbody>\n</tbody>",
+			},
+		},
+		{
+			"Escape HTML",
+			[][]Input{{{value: "<b>", runeCount: 3, line: "<b>"}}},
+			[]string{
+				`<td class="marking">&lt;b&gt;</td>`,
+				`<span class="valid">&lt;b&gt;</span>`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+			hp := NewHTMLPrinter(writer)
+			hp.SetSeparators("-")
+			for _, inputs := range tt.lines {
+				if err := hp.Print(inputs); err != nil {
+					t.Fatalf("HTMLPrinter.Print() error = %v", err)
+				}
+			}
+			if err := hp.Close(); err != nil {
+				t.Fatalf("HTMLPrinter.Close() error = %v", err)
+			}
+			gotWriter := writer.String()
+			if !strings.HasPrefix(gotWriter, "<!DOCTYPE html>") {
+				t.Errorf("gotWriter = %v, want prefix <!DOCTYPE html>", gotWriter)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(gotWriter, want) {
+					t.Errorf("gotWriter = %v, want contains %v", gotWriter, want)
+				}
+			}
+		})
+	}
+}
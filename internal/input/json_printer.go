@@ -0,0 +1,132 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package input
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ansiEscape matches ANSI escape sequences of colours and text styles.
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// stripANSI removes ANSI escape sequences.
+func stripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
+
+// jsonLine is the validation of a line. The schema is documented in
+// docs/validate-output.schema.json.
+type jsonLine struct {
+	Input string     `json:"input"`
+	Valid bool       `json:"valid"`
+	Parts []jsonPart `json:"parts"`
+}
+
+type jsonPart struct {
+	Name  string            `json:"name"`
+	Value string            `json:"value"`
+	Valid bool              `json:"valid"`
+	Error string            `json:"error,omitempty"`
+	Infos []string          `json:"infos"`
+	Data  map[string]string `json:"data"`
+}
+
+func newJSONLine(inputs []Input) jsonLine {
+	line := jsonLine{Valid: true, Parts: make([]jsonPart, 0, len(inputs))}
+	for _, input := range inputs {
+		line.Input = input.line
+		part := jsonPart{
+			Value: input.value,
+			Valid: input.err == nil,
+			Infos: make([]string, 0, len(input.infos)),
+			Data:  make(map[string]string, len(input.data)),
+		}
+		if input.err != nil {
+			part.Error = stripANSI(input.err.Error())
+			line.Valid = false
+		}
+		for _, info := range input.infos {
+			part.Infos = append(part.Infos, stripANSI(info.Text))
+		}
+		for idx, datum := range input.data {
+			if idx == 0 {
+				part.Name = datum.header
+			}
+			part.Data[datum.header] = datum.value
+		}
+		line.Parts = append(line.Parts, part)
+	}
+	return line
+}
+
+// JSONPrinter prints the inputs of every line as an object of one JSON array.
+// Use NewJSONPrinter to instantiate one and call Close after the last line.
+type JSONPrinter struct {
+	writer  io.Writer
+	printed bool
+}
+
+// NewJSONPrinter creates a JSONPrinter.
+func NewJSONPrinter(writer io.Writer) *JSONPrinter {
+	return &JSONPrinter{writer: writer}
+}
+
+// Print writes the inputs as element of the JSON array to writer.
+func (jp *JSONPrinter) Print(inputs []Input) error {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(newJSONLine(inputs)); err != nil {
+		return err
+	}
+	prefix := ",\n  "
+	if !jp.printed {
+		prefix = "[\n  "
+		jp.printed = true
+	}
+	_, err := io.WriteString(jp.writer, prefix+strings.TrimSuffix(buffer.String(), "\n"))
+	return err
+}
+
+// Close writes the end of the JSON array to writer.
+func (jp *JSONPrinter) Close() error {
+	if !jp.printed {
+		_, err := io.WriteString(jp.writer, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(jp.writer, "\n]\n")
+	return err
+}
+
+// NDJSONPrinter prints the inputs of every line as one JSON object per line.
+// Use NewNDJSONPrinter to instantiate one.
+type NDJSONPrinter struct {
+	encoder *json.Encoder
+}
+
+// NewNDJSONPrinter creates a NDJSONPrinter.
+func NewNDJSONPrinter(writer io.Writer) *NDJSONPrinter {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	return &NDJSONPrinter{encoder: encoder}
+}
+
+// Print writes the inputs as JSON object in one line to writer.
+func (np *NDJSONPrinter) Print(inputs []Input) error {
+	return np.encoder.Encode(newJSONLine(inputs))
+}
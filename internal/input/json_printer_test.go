@@ -0,0 +1,97 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package input
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func newJSONTestInputs() []Input {
+	return []Input{
+		{
+			value: "ABC",
+			line:  "ABC 1",
+			infos: []Info{{Text: "\x1b[1msome-company\x1b[0m"}},
+			data: []Datum{
+				{header: "owner-code", value: "ABC"},
+				{header: "company", value: "some-company"},
+			},
+		},
+		{
+			value: "1",
+			line:  "ABC 1",
+			err:   errors.New("\x1b[4mcheck digit\x1b[0m is not \x1b[32m0\x1b[0m"),
+			data:  []Datum{{header: "check-digit", value: "1"}},
+		},
+	}
+}
+
+func TestJSONPrinter(t *testing.T) {
+	tests := []struct {
+		name       string
+		lines      [][]Input
+		wantWriter string
+	}{
+		{
+			"Print JSON array",
+			[][]Input{newJSONTestInputs(), newJSONTestInputs()[:1]},
+			`[
+  {"input":"ABC 1","valid":false,"parts":[{"name":"owner-code","value":"ABC","valid":true,"infos":["some-company"],"data":{"company":"some-company","owner-code":"ABC"}},{"name":"check-digit","value":"1","valid":false,"error":"check digit is not 0","infos":[],"data":{"check-digit":"1"}}]},
+  {"input":"ABC 1","valid":true,"parts":[{"name":"owner-code","value":"ABC","valid":true,"infos":["some-company"],"data":{"company":"some-company","owner-code":"ABC"}}]}
+]
+`,
+		},
+		{
+			"Print empty JSON array",
+			nil,
+			`[]
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+			jp := NewJSONPrinter(writer)
+			for _, inputs := range tt.lines {
+				if err := jp.Print(inputs); err != nil {
+					t.Fatalf("JSONPrinter.Print() error = %v", err)
+				}
+			}
+			if err := jp.Close(); err != nil {
+				t.Fatalf("JSONPrinter.Close() error = %v", err)
+			}
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+		})
+	}
+}
+
+func TestNDJSONPrinter(t *testing.T) {
+	writer := &bytes.Buffer{}
+	np := NewNDJSONPrinter(writer)
+	for _, inputs := range [][]Input{newJSONTestInputs()[1:], newJSONTestInputs()[:1]} {
+		if err := np.Print(inputs); err != nil {
+			t.Fatalf("NDJSONPrinter.Print() error = %v", err)
+		}
+	}
+	want := `{"input":"ABC 1","valid":false,"parts":[{"name":"check-digit","value":"1","valid":false,"error":"check digit is not 0","infos":[],"data":{"check-digit":"1"}}]}
+{"input":"ABC 1","valid":true,"parts":[{"name":"owner-code","value":"ABC","valid":true,"infos":["some-company"],"data":{"company":"some-company","owner-code":"ABC"}}]}
+`
+	if gotWriter := writer.String(); gotWriter != want {
+		t.Errorf("gotWriter = %v, want %v", gotWriter, want)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package input
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const summaryTopOwners = 10
+
+type summaryCount struct {
+	key   string
+	text  string
+	count int
+}
+
+// SummaryPrinter prints the output of another printer and a summary of all
+// lines to a separate writer on Close. Use NewSummaryPrinter to instantiate one.
+type SummaryPrinter struct {
+	printer   Printer
+	writer    io.Writer
+	total     int
+	valid     int
+	errors    map[string]int
+	owners    map[string]*summaryCount
+	countries map[string]*summaryCount
+}
+
+// NewSummaryPrinter creates a SummaryPrinter that wraps printer and writes the
+// summary to writer.
+func NewSummaryPrinter(printer Printer, writer io.Writer) *SummaryPrinter {
+	return &SummaryPrinter{
+		printer:   printer,
+		writer:    writer,
+		errors:    map[string]int{},
+		owners:    map[string]*summaryCount{},
+		countries: map[string]*summaryCount{},
+	}
+}
+
+var summaryErrors = []struct {
+	key   string
+	title string
+}{
+	{"owner-code", "unknown owner"},
+	{"check-digit", "bad check digit"},
+	{"length-code", "bad length code"},
+	{"type-code", "bad type code"},
+	{"possible-transposition-error", "possible transposition"},
+}
+
+// Print prints the inputs with the wrapped printer and counts them for the summary.
+func (sp *SummaryPrinter) Print(inputs []Input) error {
+	if err := sp.printer.Print(inputs); err != nil {
+		return err
+	}
+	sp.total++
+	valid := true
+	for _, input := range inputs {
+		if input.err != nil {
+			valid = false
+		}
+		if len(input.data) == 0 {
+			continue
+		}
+		data := map[string]string{}
+		for _, datum := range input.data {
+			data[datum.header] = datum.value
+		}
+		switch input.data[0].header {
+		case "owner-code":
+			// an owner code with a valid format and an error is not registered
+			if input.err != nil && input.value != "" {
+				sp.errors["owner-code"]++
+			}
+			if code := data["owner-code"]; code != "" {
+				countSummary(sp.owners, code, data["company"])
+			}
+			if country := data["country"]; country != "" {
+				countSummary(sp.countries, country, "")
+			}
+		case "check-digit":
+			// without calculated check digit the check digit is not calculable
+			if input.err != nil && data["calculated-check-digit"] != "" {
+				sp.errors["check-digit"]++
+			}
+			if data["possible-transposition-error"] != "" {
+				sp.errors["possible-transposition-error"]++
+			}
+		case "length-code", "type-code":
+			if input.err != nil {
+				sp.errors[input.data[0].header]++
+			}
+		}
+	}
+	if valid {
+		sp.valid++
+	}
+	return nil
+}
+
+func countSummary(counts map[string]*summaryCount, key, text string) {
+	if counts[key] == nil {
+		counts[key] = &summaryCount{key: key, text: text}
+	}
+	counts[key].count++
+}
+
+// Close closes the wrapped printer if it needs to be closed and writes the summary.
+func (sp *SummaryPrinter) Close() error {
+	if closer, ok := sp.printer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(sp.writer, sp.fmtSummary())
+	return err
+}
+
+func (sp *SummaryPrinter) fmtSummary() string {
+	b := strings.Builder{}
+
+	rows := [][]string{
+		{"lines", strconv.Itoa(sp.total)},
+		{"valid", strconv.Itoa(sp.valid)},
+		{"invalid", strconv.Itoa(sp.total - sp.valid)},
+	}
+	for _, summaryErr := range summaryErrors {
+		rows = append(rows, []string{summaryErr.title, strconv.Itoa(sp.errors[summaryErr.key])})
+	}
+	writeSummarySection(&b, "Summary", rows)
+
+	owners := sortedSummaryCounts(sp.owners)
+	if len(owners) > summaryTopOwners {
+		owners = owners[:summaryTopOwners]
+	}
+	rows = nil
+	for _, owner := range owners {
+		rows = append(rows, []string{owner.key + "  " + owner.text, strconv.Itoa(owner.count)})
+	}
+	writeSummarySection(&b, "Top owners", rows)
+
+	rows = nil
+	for _, country := range sortedSummaryCounts(sp.countries) {
+		rows = append(rows, []string{country.key, strconv.Itoa(country.count)})
+	}
+	writeSummarySection(&b, "Countries of owners", rows)
+
+	return b.String()
+}
+
+func writeSummarySection(b *strings.Builder, title string, rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	titleWidth, countWidth := 0, 0
+	for _, row := range rows {
+		if w := runeCount(row[0]); w > titleWidth {
+			titleWidth = w
+		}
+		if w := len(row[1]); w > countWidth {
+			countWidth = w
+		}
+	}
+	b.WriteString(fmt.Sprintln())
+	b.WriteString(fmt.Sprintln(bold(title)))
+	for _, row := range rows {
+		b.WriteString(fmt.Sprintf("  %s%s  %*s\n",
+			row[0], strings.Repeat(" ", titleWidth-runeCount(row[0])), countWidth, row[1]))
+	}
+}
+
+func sortedSummaryCounts(counts map[string]*summaryCount) []summaryCount {
+	var sorted []summaryCount
+	for _, count := range counts {
+		sorted = append(sorted, *count)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].count != sorted[j].count {
+			return sorted[i].count > sorted[j].count
+		}
+		return sorted[i].key < sorted[j].key
+	})
+	return sorted
+}
@@ -0,0 +1,151 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package input
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func newSummaryTestInputs(ownerCode, country string) []Input {
+	return []Input{
+		{value: ownerCode, runeCount: 3, data: []Datum{
+			{header: "owner-code", value: ownerCode},
+			{header: "company", value: "company-" + ownerCode},
+			{header: "country", value: country},
+		}},
+	}
+}
+
+func TestSummaryPrinter(t *testing.T) {
+	var manyOwners [][]Input
+	manyOwnersWriter := ""
+	for idx := 0; idx < 12; idx++ {
+		for count := 0; count <= idx; count++ {
+			ownerCode := fmt.Sprintf("A%02d", idx)
+			manyOwners = append(manyOwners, newSummaryTestInputs(ownerCode, "some-country"))
+			manyOwnersWriter += ownerCode + "\n"
+		}
+	}
+	tests := []struct {
+		name          string
+		lines         [][]Input
+		wantWriter    string
+		wantWriterErr string
+	}{
+		{
+			"Print summary",
+			[][]Input{
+				newSummaryTestInputs("ABC", "country-b"),
+				newSummaryTestInputs("DEF", "country-a"),
+				newSummaryTestInputs("DEF", "country-a"),
+				{{value: "XYZ", runeCount: 3, err: errors.New("XYZ is not registered"), data: []Datum{
+					{header: "owner-code"},
+				}}},
+				{{value: "", runeCount: 3, err: errors.New("owner code is not 3 letters long"), data: []Datum{
+					{header: "owner-code"},
+				}}},
+			},
+			"ABC\nDEF\nDEF\nXYZ\n\nclosed\n",
+			`
+Summary
+  lines                   5
+  valid                   3
+  invalid                 2
+  unknown owner           1
+  bad check digit         0
+  bad length code         0
+  bad type code           0
+  possible transposition  0
+
+Top owners
+  DEF  company-DEF  2
+  ABC  company-ABC  1
+
+Countries of owners
+  country-a  2
+  country-b  1
+`,
+		},
+		{
+			"Print only top owners",
+			manyOwners,
+			manyOwnersWriter + "closed\n",
+			`
+Summary
+  lines                   78
+  valid                   78
+  invalid                  0
+  unknown owner            0
+  bad check digit          0
+  bad length code          0
+  bad type code            0
+  possible transposition   0
+
+Top owners
+  A11  company-A11  12
+  A10  company-A10  11
+  A09  company-A09  10
+  A08  company-A08   9
+  A07  company-A07   8
+  A06  company-A06   7
+  A05  company-A05   6
+  A04  company-A04   5
+  A03  company-A03   4
+  A02  company-A02   3
+
+Countries of owners
+  some-country  78
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+			writerErr := &bytes.Buffer{}
+			sp := NewSummaryPrinter(&valuePrinter{writer: writer}, writerErr)
+			for _, inputs := range tt.lines {
+				if err := sp.Print(inputs); err != nil {
+					t.Fatalf("SummaryPrinter.Print() error = %v", err)
+				}
+			}
+			if err := sp.Close(); err != nil {
+				t.Fatalf("SummaryPrinter.Close() error = %v", err)
+			}
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+			if gotWriterErr := writerErr.String(); gotWriterErr != tt.wantWriterErr {
+				t.Errorf("gotWriterErr = %v, want %v", gotWriterErr, tt.wantWriterErr)
+			}
+		})
+	}
+}
+
+type valuePrinter struct {
+	writer io.Writer
+}
+
+func (vp *valuePrinter) Print(inputs []Input) error {
+	_, err := io.WriteString(vp.writer, inputs[0].value+"\n")
+	return err
+}
+
+func (vp *valuePrinter) Close() error {
+	_, err := io.WriteString(vp.writer, "closed\n")
+	return err
+}
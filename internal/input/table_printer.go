@@ -0,0 +1,244 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package input
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+var bold = color.New(color.Bold).SprintFunc()
+
+// minTruncatedWidth is the minimal width of a truncated column.
+const minTruncatedWidth = 8
+
+var tableHeaders = []string{"number", "", "company", "size and type", "error"}
+
+// truncatable marks the columns that are truncated to fit the width.
+var truncatable = []bool{false, false, true, true, true}
+
+type tableRow struct {
+	inputs     []Input
+	separators []string
+	valid      bool
+	cells      []string
+}
+
+// TablePrinter prints the inputs of every line as a row of an aligned table.
+// Rows are buffered and printed on Close. Use NewTablePrinter to instantiate one.
+type TablePrinter struct {
+	writer         io.Writer
+	width          int
+	separators     []string
+	separatorsFunc func(inputs []Input)
+	rows           []tableRow
+}
+
+// NewTablePrinter creates a TablePrinter. Rows longer than width are truncated.
+// A width less than 1 means no truncation.
+func NewTablePrinter(writer io.Writer, width int) *TablePrinter {
+	return &TablePrinter{
+		writer: writer,
+		width:  width,
+	}
+}
+
+// SetSeparators sets the separators between inputs of the number column. Default separator is ' '.
+func (tp *TablePrinter) SetSeparators(separators ...string) {
+	tp.separators = separators
+}
+
+// SetSeparatorsFunc sets a function that can set the separators depending on inputs.
+func (tp *TablePrinter) SetSeparatorsFunc(separatorsFunc func(inputs []Input)) {
+	tp.separatorsFunc = separatorsFunc
+}
+
+// Print buffers the inputs as row.
+func (tp *TablePrinter) Print(inputs []Input) error {
+	if tp.separatorsFunc != nil {
+		tp.separatorsFunc(inputs)
+	}
+	row := tableRow{inputs: inputs, separators: tp.separators}
+
+	valid := true
+	errMsg := ""
+	data := map[string]string{}
+	for _, input := range inputs {
+		if input.err != nil {
+			valid = false
+			if errMsg == "" {
+				errMsg = stripANSI(input.err.Error())
+			}
+		}
+		for _, datum := range input.data {
+			data[datum.header] = datum.value
+		}
+	}
+	var sizeType []string
+	for _, header := range []string{"length-description", "height-description", "width-description",
+		"type-description"} {
+		if data[header] != "" {
+			sizeType = append(sizeType, data[header])
+		}
+	}
+	row.valid = valid
+	row.cells = []string{
+		row.plainNumber(),
+		stripANSI(fmtMark(valid)),
+		data["company"],
+		strings.Join(sizeType, ", "),
+		errMsg,
+	}
+	tp.rows = append(tp.rows, row)
+	return nil
+}
+
+// Close writes the aligned table of all rows to writer.
+func (tp *TablePrinter) Close() error {
+	if len(tp.rows) == 0 {
+		return nil
+	}
+	widths := tp.columnWidths()
+
+	b := strings.Builder{}
+	headers := make([]string, len(tableHeaders))
+	for idx, header := range tableHeaders {
+		headers[idx] = bold(truncate(header, widths[idx]))
+	}
+	writeRow(&b, widths, headers)
+	for _, row := range tp.rows {
+		cells := []string{row.number(), fmtMark(row.valid)}
+		for _, cell := range row.cells[2:] {
+			cells = append(cells, truncate(cell, widths[len(cells)]))
+		}
+		writeRow(&b, widths, cells)
+	}
+	_, err := io.WriteString(tp.writer, b.String())
+	return err
+}
+
+// writeRow writes the cells of columns with a width greater than 0. Cells are
+// padded to the width of their column without trailing spaces of the row.
+func writeRow(b *strings.Builder, widths []int, cells []string) {
+	line := strings.Builder{}
+	for idx, cell := range cells {
+		if widths[idx] == 0 {
+			continue
+		}
+		if idx > 0 {
+			line.WriteString("  ")
+		}
+		line.WriteString(cell)
+		line.WriteString(strings.Repeat(" ", widths[idx]-runeCount(cell)))
+	}
+	b.WriteString(strings.TrimRight(line.String(), " "))
+	b.WriteString(fmt.Sprintln())
+}
+
+// columnWidths returns the widths of the columns. Truncatable columns are
+// shrunk from the widest to the narrowest until the table fits the width.
+// Empty columns have width 0.
+func (tp *TablePrinter) columnWidths() []int {
+	widths := make([]int, len(tableHeaders))
+	for _, row := range tp.rows {
+		for idx, cell := range row.cells {
+			if w := runeCount(cell); w > widths[idx] {
+				widths[idx] = w
+			}
+		}
+	}
+	total := -2
+	for idx, w := range widths {
+		if w == 0 {
+			continue
+		}
+		if w < utf8.RuneCountInString(tableHeaders[idx]) {
+			widths[idx] = utf8.RuneCountInString(tableHeaders[idx])
+		}
+		total += widths[idx] + 2
+	}
+	for tp.width > 0 && total > tp.width {
+		widest := -1
+		for idx, w := range widths {
+			if truncatable[idx] && w > minTruncatedWidth && (widest == -1 || w > widths[widest]) {
+				widest = idx
+			}
+		}
+		if widest == -1 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// plainNumber returns the values of the inputs with separators without colours.
+// Missing values are replaced by underscores.
+func (r tableRow) plainNumber() string {
+	return r.fmtNumber(func(input Input) string {
+		if input.err == nil || input.isValidFmt() {
+			return input.value
+		}
+		return strings.Repeat("_", input.runeCount)
+	})
+}
+
+// number returns the values of the inputs with separators coloured like the fancy output.
+func (r tableRow) number() string {
+	return r.fmtNumber(fmtValue)
+}
+
+func (r tableRow) fmtNumber(fmtValue func(input Input) string) string {
+	b := strings.Builder{}
+	for idx, input := range r.inputs {
+		b.WriteString(fmtValue(input))
+		switch {
+		case idx == len(r.inputs)-1:
+		case idx < len(r.separators):
+			b.WriteString(r.separators[idx])
+		default:
+			b.WriteString(" ")
+		}
+	}
+	return b.String()
+}
+
+func fmtMark(valid bool) string {
+	if valid {
+		return green("✔")
+	}
+	return red("✘")
+}
+
+// runeCount returns the count of runes without ANSI escape sequences.
+func runeCount(s string) int {
+	return utf8.RuneCountInString(stripANSI(s))
+}
+
+// truncate shortens s to width runes and marks the truncation with '…'.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width < 1 {
+		return ""
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,92 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package input
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func newTableTestLines() [][]Input {
+	return [][]Input{
+		{
+			{value: "ABC", runeCount: 3, data: []Datum{{header: "company", value: "some-company"}}},
+			{value: "U", runeCount: 1},
+			{value: "123456", runeCount: 6},
+			{value: "0", runeCount: 1},
+			{value: "2", runeCount: 1, data: []Datum{{header: "length-description", value: "some-length"}}},
+			{value: "0", runeCount: 1, data: []Datum{{header: "height-description", value: "some-height"}}},
+			{value: "G1", runeCount: 2, data: []Datum{{header: "type-description", value: "some-type"}}},
+		},
+		{
+			{value: "DEF", runeCount: 3, err: errors.New("\x1b[4mDEF\x1b[0m is not registered")},
+			{value: "U", runeCount: 1},
+			{value: "", runeCount: 6, err: errors.New("serial number is not 6 numbers long")},
+			{value: "", runeCount: 1, err: errors.New("check digit is not calculable")},
+		},
+	}
+}
+
+func TestTablePrinter(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		lines      [][]Input
+		wantWriter string
+	}{
+		{
+			"Print table",
+			0,
+			newTableTestLines(),
+			`number                    company       size and type                        error
+ABC-U 123456 0 2 0 G1  ✔  some-company  some-length, some-height, some-type
+DEF-U ______ _         ✘                                                     DEF is not registered
+`,
+		},
+		{
+			"Print table truncated to width",
+			60,
+			newTableTestLines(),
+			`number                    company     size and …  error
+ABC-U 123456 0 2 0 G1  ✔  some-comp…  some-leng…
+DEF-U ______ _         ✘                          DEF is no…
+`,
+		},
+		{
+			"Print nothing without lines",
+			0,
+			nil,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+			tp := NewTablePrinter(writer, tt.width)
+			tp.SetSeparators("-")
+			for _, inputs := range tt.lines {
+				if err := tp.Print(inputs); err != nil {
+					t.Fatalf("TablePrinter.Print() error = %v", err)
+				}
+			}
+			if err := tp.Close(); err != nil {
+				t.Fatalf("TablePrinter.Close() error = %v", err)
+			}
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+		})
+	}
+}
@@ -21,11 +21,13 @@ import (
 // Validate validates inputs. Each input is validated and values are assigned.
 func Validate(in string, newInputs []func() Input) ([]Input, error) {
 
+	line := in
 	previousValues := make([]string, 0)
 	inputs := make([]Input, 0)
 	var err error
 	for _, newInput := range newInputs {
 		input := newInput()
+		input.line = line
 		input.previousValues = previousValues
 
 		matchIndex := input.matchIndex(in)
@@ -57,6 +59,7 @@ type Input struct {
 	validate       func(value string, previousValues []string) (error, []Info, []Datum)
 	toUpper        bool
 	value          string
+	line           string
 	previousValues []string
 	err            error
 	infos          []Info
@@ -75,6 +78,31 @@ func NewInput(runeCount int,
 	return Input{runeCount: runeCount, matchIndex: matchIndex, validate: validate}
 }
 
+// Value returns the matched value.
+func (i *Input) Value() string {
+	return i.value
+}
+
+// AppendInfos appends infos to the infos of the validation.
+func (i *Input) AppendInfos(infos ...Info) {
+	i.infos = append(i.infos, infos...)
+}
+
+// AppendData appends data to the data of the validation.
+func (i *Input) AppendData(data ...Datum) {
+	i.data = append(i.data, data...)
+}
+
+// HasDatum returns true if the input has a datum with the header.
+func (i *Input) HasDatum(header string) bool {
+	for _, datum := range i.data {
+		if datum.header == header {
+			return true
+		}
+	}
+	return false
+}
+
 func (i *Input) validateValue() {
 	i.err, i.infos, i.data = i.validate(i.value, i.previousValues)
 }
@@ -158,3 +158,14 @@ func TestInputHasCorrectValue(t *testing.T) {
 		})
 	}
 }
+
+func TestInput_HasDatum(t *testing.T) {
+	var input Input
+	input.AppendData(NewDatum("check-digit").WithValue("0"))
+	if !input.HasDatum("check-digit") {
+		t.Errorf("Input.HasDatum() = false, want true")
+	}
+	if input.HasDatum("serial-number") {
+		t.Errorf("Input.HasDatum() = true, want false")
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contnum_test
+
+import (
+	"fmt"
+
+	"github.com/meyermarcel/icm/pkg/contnum"
+)
+
+func ExampleParse() {
+	number, err := contnum.Parse("csqu 305438 3")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(number.OwnerCode, number.EquipCatID, number.SerialNumber, number.CheckDigit)
+	// Output: CSQ U 305438 3
+}
+
+func ExampleParse_error() {
+	_, err := contnum.Parse("CSQ U 305438 4")
+	fmt.Println(err)
+	// Output: check digit: 4 is not calculated check digit 3
+}
+
+type owners map[string]contnum.Owner
+
+func (o owners) Decode(code string) (bool, contnum.Owner) {
+	owner, ok := o[code]
+	return ok, owner
+}
+
+func ExampleNewValidatorFromDecoders() {
+	validator := contnum.NewValidatorFromDecoders(contnum.Decoders{
+		Owner: owners{"CSQ": {Code: "CSQ", Company: "some-company", City: "some-city", Country: "some-country"}},
+	})
+	result := validator.Validate("CSQ U 305438 3")
+	fmt.Println(result.Valid(), result.Owner.Company)
+	result = validator.Validate("XYZ U 123456 0")
+	fmt.Println(result.Valid(), result.Errors)
+	// Output:
+	// true some-company
+	// false [owner code: XYZ is not registered]
+}
+
+func ExampleValidate() {
+	result := contnum.Validate("WSL U 801743 0   22G1")
+	fmt.Println(result.Valid(), result.Number, result.Number.SizeType)
+	for _, transposed := range result.PossibleTranspositionErrors {
+		fmt.Println(transposed)
+	}
+	// Output:
+	// true WSLU8017430 22G1
+	// WSLU8107430
+	// WSLU8071430
+	// WSLU8017403
+}
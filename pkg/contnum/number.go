@@ -0,0 +1,187 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package contnum parses and validates intermodal container numbers and
+// size and type codes as they are used by the icm command.
+package contnum
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/meyermarcel/icm/internal/cont"
+)
+
+// Part is a part of a container marking.
+type Part int
+
+// Parts of a container marking in the order they are marked on a container.
+const (
+	PartOwner Part = iota
+	PartEquipCat
+	PartSerialNum
+	PartCheckDigit
+	PartLength
+	PartHeightWidth
+	PartType
+)
+
+var partNames = []string{
+	"owner code",
+	"equipment category id",
+	"serial number",
+	"check digit",
+	"length code",
+	"height and width code",
+	"type code",
+}
+
+func (p Part) String() string {
+	if p < 0 || int(p) >= len(partNames) {
+		return fmt.Sprintf("part %d", int(p))
+	}
+	return partNames[p]
+}
+
+// PartError is an error for an invalid part of a container marking.
+type PartError struct {
+	Part  Part
+	Value string
+	Err   error
+}
+
+func (e *PartError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Part, e.Err)
+}
+
+// Number is a container number with an optional size and type code.
+type Number struct {
+	OwnerCode    string
+	EquipCatID   string
+	SerialNumber string
+	CheckDigit   int
+	SizeType     SizeType
+}
+
+// String returns the container number without separators, e.g. ABCU1234560.
+// The size and type code is not part of the returned string.
+func (n Number) String() string {
+	return fmt.Sprintf("%s%s%s%d", n.OwnerCode, n.EquipCatID, n.SerialNumber, n.CheckDigit)
+}
+
+// SizeType is the size and type code of a container marking. All codes are
+// empty if a marking has no size and type code.
+type SizeType struct {
+	LengthCode      string
+	HeightWidthCode string
+	TypeCode        string
+}
+
+// IsZero reports whether no size and type code is set.
+func (st SizeType) IsZero() bool {
+	return st == SizeType{}
+}
+
+// String returns the size and type code without separators, e.g. 22G1.
+func (st SizeType) String() string {
+	return st.LengthCode + st.HeightWidthCode + st.TypeCode
+}
+
+// Parse parses a container number with an optional size and type code, e.g.
+// "ABC U 123456 0" or "ABCU1234560 22G1". All characters except letters and
+// digits are treated as separators and lower case letters are converted to
+// upper case. Parse returns an error if a part has an invalid format or the
+// check digit is not correct. Use Validate for further information.
+func Parse(s string) (Number, error) {
+	number, errs := parse(s)
+	if len(errs) != 0 {
+		return Number{}, errs[0]
+	}
+	return number, nil
+}
+
+func parse(s string) (Number, []error) {
+	chars := normalize(s)
+	if len(chars) != 11 && len(chars) != 15 {
+		return Number{}, []error{fmt.Errorf("%s is not 11 or 15 letters and digits long", s)}
+	}
+
+	var errs []error
+	addErr := func(part Part, value string, err error) {
+		if err != nil {
+			errs = append(errs, &PartError{Part: part, Value: value, Err: err})
+		}
+	}
+
+	number := Number{
+		OwnerCode:    chars[0:3],
+		EquipCatID:   chars[3:4],
+		SerialNumber: chars[4:10],
+	}
+	addErr(PartOwner, number.OwnerCode, cont.IsOwnerCode(number.OwnerCode))
+	addErr(PartEquipCat, number.EquipCatID, cont.IsEquipCatID(number.EquipCatID))
+	addErr(PartSerialNum, number.SerialNumber, isSerialNum(number.SerialNumber))
+
+	checkDigit, err := strconv.Atoi(chars[10:11])
+	if err != nil {
+		addErr(PartCheckDigit, chars[10:11], cont.NewErrContValidate(
+			fmt.Sprintf("%s is not 1 digit", chars[10:11])))
+	} else {
+		number.CheckDigit = checkDigit
+		if len(errs) == 0 {
+			calculated := cont.CalcCheckDigit(number.OwnerCode, number.EquipCatID, number.SerialNumber)
+			if checkDigit != calculated%10 {
+				addErr(PartCheckDigit, chars[10:11], cont.NewErrContValidate(
+					fmt.Sprintf("%d is not calculated check digit %d", checkDigit, calculated%10)))
+			}
+		}
+	}
+
+	if len(chars) == 15 {
+		number.SizeType = SizeType{
+			LengthCode:      chars[11:12],
+			HeightWidthCode: chars[12:13],
+			TypeCode:        chars[13:15],
+		}
+		addErr(PartLength, number.SizeType.LengthCode, cont.IsLengthCode(number.SizeType.LengthCode))
+		addErr(PartHeightWidth, number.SizeType.HeightWidthCode,
+			cont.IsHeightWidthCode(number.SizeType.HeightWidthCode))
+		addErr(PartType, number.SizeType.TypeCode, cont.IsTypeCode(number.SizeType.TypeCode))
+	}
+	return number, errs
+}
+
+func normalize(s string) string {
+	b := strings.Builder{}
+	for _, r := range s {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			continue
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+func isSerialNum(serialNum string) error {
+	if len(serialNum) != 6 {
+		return cont.NewErrContValidate(fmt.Sprintf("%s is not 6 digits long", serialNum))
+	}
+	for _, r := range serialNum {
+		if r < '0' || r > '9' {
+			return cont.NewErrContValidate(fmt.Sprintf("%s is not 6 digits", serialNum))
+		}
+	}
+	return nil
+}
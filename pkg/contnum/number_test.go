@@ -0,0 +1,130 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contnum
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		want     Number
+		wantPart Part
+		wantErr  bool
+	}{
+		{
+			"Parse container number with separators",
+			"ABC U 123456 0",
+			Number{OwnerCode: "ABC", EquipCatID: "U", SerialNumber: "123456", CheckDigit: 0},
+			0,
+			false,
+		},
+		{
+			"Parse lower case container number without separators",
+			"csqu3054383",
+			Number{OwnerCode: "CSQ", EquipCatID: "U", SerialNumber: "305438", CheckDigit: 3},
+			0,
+			false,
+		},
+		{
+			"Parse container number with check digit 10",
+			"CMA U 163912 0",
+			Number{OwnerCode: "CMA", EquipCatID: "U", SerialNumber: "163912", CheckDigit: 0},
+			0,
+			false,
+		},
+		{
+			"Parse container number with size and type",
+			"ABC U 123456 0   22 G1",
+			Number{
+				OwnerCode:    "ABC",
+				EquipCatID:   "U",
+				SerialNumber: "123456",
+				CheckDigit:   0,
+				SizeType:     SizeType{LengthCode: "2", HeightWidthCode: "2", TypeCode: "G1"},
+			},
+			0,
+			false,
+		},
+		{
+			"Parse returns error for wrong check digit",
+			"ABC U 123456 1",
+			Number{},
+			PartCheckDigit,
+			true,
+		},
+		{
+			"Parse returns error for digit in owner code",
+			"AB1 U 123456 0",
+			Number{},
+			PartOwner,
+			true,
+		},
+		{
+			"Parse returns error for letter in serial number",
+			"ABC U 12345A 0",
+			Number{},
+			PartSerialNum,
+			true,
+		},
+		{
+			"Parse returns error for invalid type code",
+			"ABC U 123456 0 22G-",
+			Number{},
+			-1,
+			true,
+		},
+		{
+			"Parse returns error for too short input",
+			"ABC U 12345",
+			Number{},
+			-1,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if partErr, ok := err.(*PartError); ok && partErr.Part != tt.wantPart {
+				t.Errorf("Parse() error part = %v, want %v", partErr.Part, tt.wantPart)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumber_String(t *testing.T) {
+	number := Number{
+		OwnerCode:    "ABC",
+		EquipCatID:   "U",
+		SerialNumber: "123456",
+		CheckDigit:   0,
+		SizeType:     SizeType{LengthCode: "2", HeightWidthCode: "2", TypeCode: "G1"},
+	}
+	if got := number.String(); got != "ABCU1234560" {
+		t.Errorf("Number.String() = %v, want %v", got, "ABCU1234560")
+	}
+	if got := number.SizeType.String(); got != "22G1" {
+		t.Errorf("SizeType.String() = %v, want %v", got, "22G1")
+	}
+}
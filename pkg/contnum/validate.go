@@ -0,0 +1,272 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contnum
+
+import (
+	"fmt"
+
+	"github.com/meyermarcel/icm/internal/cont"
+	"github.com/meyermarcel/icm/internal/data"
+	"github.com/meyermarcel/icm/internal/file"
+)
+
+// Owner has a code and associated company with its location in the form of country and city.
+type Owner struct {
+	Code    string
+	Company string
+	City    string
+	Country string
+}
+
+// Result is the result of a validation. Information of a part is only
+// available if the part is valid and the Validator has data for it.
+type Result struct {
+	Number                      Number
+	Errors                      []error
+	Owner                       Owner
+	EquipCat                    string
+	Length                      string
+	Height                      string
+	Width                       string
+	TypeInfo                    string
+	GroupInfo                   string
+	PossibleTranspositionErrors []Number
+}
+
+// Valid reports whether all parts are valid.
+func (r Result) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// OwnerDecoder decodes an owner code to an owner.
+type OwnerDecoder interface {
+	Decode(code string) (bool, Owner)
+}
+
+// EquipCatDecoder decodes an equipment category ID to its description.
+type EquipCatDecoder interface {
+	Decode(ID string) (bool, string)
+}
+
+// LengthDecoder decodes a length code to a length.
+type LengthDecoder interface {
+	Decode(code string) (bool, string)
+}
+
+// HeightWidthDecoder decodes a height and width code to a height and a width.
+type HeightWidthDecoder interface {
+	Decode(code string) (found bool, height, width string)
+}
+
+// TypeDecoder decodes a type code to a type and its group description.
+type TypeDecoder interface {
+	Decode(code string) (found bool, typeInfo, groupInfo string)
+}
+
+// Decoders are the data sources of a Validator. Parts without a decoder are
+// only validated by format.
+type Decoders struct {
+	Owner       OwnerDecoder
+	EquipCat    EquipCatDecoder
+	Length      LengthDecoder
+	HeightWidth HeightWidthDecoder
+	Type        TypeDecoder
+}
+
+// Validator validates container markings with data of owners,
+// equipment categories, sizes and types.
+type Validator struct {
+	ownerDecoder       OwnerDecoder
+	equipCatDecoder    EquipCatDecoder
+	lengthDecoder      LengthDecoder
+	heightWidthDecoder HeightWidthDecoder
+	typeDecoder        TypeDecoder
+}
+
+// NewValidatorFromDecoders returns a Validator that uses decoders as data
+// sources. No files are read or written.
+func NewValidatorFromDecoders(decoders Decoders) *Validator {
+	return &Validator{
+		ownerDecoder:       decoders.Owner,
+		equipCatDecoder:    decoders.EquipCat,
+		lengthDecoder:      decoders.Length,
+		heightWidthDecoder: decoders.HeightWidth,
+		typeDecoder:        decoders.Type,
+	}
+}
+
+// NewValidator returns a Validator that uses the data files in path. Missing
+// data files are written to path with the same content the icm command uses.
+// Use NewValidatorFromDecoders to validate without a data directory.
+func NewValidator(path string) (*Validator, error) {
+	ownerDecoder, err := file.NewOwnerDecoderUpdater(path)
+	if err != nil {
+		return nil, err
+	}
+	equipCatDecoder, err := file.NewEquipCatDecoder(path)
+	if err != nil {
+		return nil, err
+	}
+	lengthDecoder, heightWidthDecoder, err := file.NewSizeDecoder(path)
+	if err != nil {
+		return nil, err
+	}
+	typeDecoder, err := file.NewTypeDecoder(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewValidatorFromDecoders(Decoders{
+		Owner:       ownerDecoderAdapter{ownerDecoder},
+		EquipCat:    equipCatDecoderAdapter{equipCatDecoder},
+		Length:      lengthDecoderAdapter{lengthDecoder},
+		HeightWidth: heightWidthDecoderAdapter{heightWidthDecoder},
+		Type:        typeDecoderAdapter{typeDecoder},
+	}), nil
+}
+
+type ownerDecoderAdapter struct {
+	decoder data.OwnerDecoder
+}
+
+func (a ownerDecoderAdapter) Decode(code string) (bool, Owner) {
+	found, owner := a.decoder.Decode(code)
+	return found, Owner(owner)
+}
+
+type equipCatDecoderAdapter struct {
+	decoder data.EquipCatDecoder
+}
+
+func (a equipCatDecoderAdapter) Decode(ID string) (bool, string) {
+	found, equipCat := a.decoder.Decode(ID)
+	return found, equipCat.Info
+}
+
+type lengthDecoderAdapter struct {
+	decoder data.LengthDecoder
+}
+
+func (a lengthDecoderAdapter) Decode(code string) (bool, string) {
+	found, length := a.decoder.Decode(code)
+	return found, length.Length
+}
+
+type heightWidthDecoderAdapter struct {
+	decoder data.HeightWidthDecoder
+}
+
+func (a heightWidthDecoderAdapter) Decode(code string) (bool, string, string) {
+	found, heightWidth := a.decoder.Decode(code)
+	return found, heightWidth.Height, heightWidth.Width
+}
+
+type typeDecoderAdapter struct {
+	decoder data.TypeDecoder
+}
+
+func (a typeDecoderAdapter) Decode(code string) (bool, string, string) {
+	found, typeAndGroup := a.decoder.Decode(code)
+	return found, typeAndGroup.TypeInfo, typeAndGroup.GroupInfo
+}
+
+// Validate validates the format and the check digit of a container marking.
+// Owner codes, equipment category IDs, sizes and types are not looked up.
+// Use a Validator to validate against registered data.
+func Validate(s string) Result {
+	return (&Validator{}).Validate(s)
+}
+
+// Validate validates a container marking. See Parse for the accepted format.
+func (v *Validator) Validate(s string) Result {
+	number, errs := parse(s)
+	result := Result{Number: number, Errors: errs}
+	if number == (Number{}) {
+		return result
+	}
+
+	invalid := map[Part]bool{}
+	for _, err := range errs {
+		if partErr, ok := err.(*PartError); ok {
+			invalid[partErr.Part] = true
+		}
+	}
+	addErr := func(part Part, value, message string) {
+		invalid[part] = true
+		result.Errors = append(result.Errors,
+			&PartError{Part: part, Value: value, Err: cont.NewErrContValidate(message)})
+	}
+
+	if v.ownerDecoder != nil && !invalid[PartOwner] {
+		found, owner := v.ownerDecoder.Decode(number.OwnerCode)
+		if found {
+			result.Owner = owner
+		} else {
+			addErr(PartOwner, number.OwnerCode, fmt.Sprintf("%s is not registered", number.OwnerCode))
+		}
+	}
+	if v.equipCatDecoder != nil && !invalid[PartEquipCat] {
+		found, equipCat := v.equipCatDecoder.Decode(number.EquipCatID)
+		if found {
+			result.EquipCat = equipCat
+		} else {
+			addErr(PartEquipCat, number.EquipCatID, fmt.Sprintf("%s is not a known category", number.EquipCatID))
+		}
+	}
+
+	if !invalid[PartOwner] && !invalid[PartEquipCat] && !invalid[PartSerialNum] && !invalid[PartCheckDigit] {
+		for _, transposed := range cont.CheckTransposition(number.OwnerCode, number.EquipCatID, number.SerialNumber) {
+			result.PossibleTranspositionErrors = append(result.PossibleTranspositionErrors, fromContNum(transposed))
+		}
+	}
+
+	if number.SizeType.IsZero() {
+		return result
+	}
+	if v.lengthDecoder != nil && !invalid[PartLength] {
+		found, length := v.lengthDecoder.Decode(number.SizeType.LengthCode)
+		if found {
+			result.Length = length
+		} else {
+			addErr(PartLength, number.SizeType.LengthCode,
+				fmt.Sprintf("%s is not valid", number.SizeType.LengthCode))
+		}
+	}
+	if v.heightWidthDecoder != nil && !invalid[PartHeightWidth] {
+		found, height, width := v.heightWidthDecoder.Decode(number.SizeType.HeightWidthCode)
+		if found {
+			result.Height, result.Width = height, width
+		} else {
+			addErr(PartHeightWidth, number.SizeType.HeightWidthCode,
+				fmt.Sprintf("%s is not valid", number.SizeType.HeightWidthCode))
+		}
+	}
+	if v.typeDecoder != nil && !invalid[PartType] {
+		found, typeInfo, groupInfo := v.typeDecoder.Decode(number.SizeType.TypeCode)
+		if found {
+			result.TypeInfo, result.GroupInfo = typeInfo, groupInfo
+		} else {
+			addErr(PartType, number.SizeType.TypeCode, fmt.Sprintf("%s is not valid", number.SizeType.TypeCode))
+		}
+	}
+	return result
+}
+
+func fromContNum(contNum cont.Number) Number {
+	return Number{
+		OwnerCode:    contNum.OwnerCode(),
+		EquipCatID:   contNum.EquipCatID(),
+		SerialNumber: contNum.SerialNumber(),
+		CheckDigit:   contNum.CheckDigit(),
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright © 2018 Marcel Meyer meyermarcel@posteo.de
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contnum
+
+import (
+	"reflect"
+	"testing"
+)
+
+type dummyOwnerDecoder struct {
+}
+
+func (dummyOwnerDecoder) Decode(code string) (bool, Owner) {
+	if code != "ABC" && code != "WSL" {
+		return false, Owner{}
+	}
+	return true, Owner{
+		Code:    code,
+		Company: "some-company",
+		City:    "some-city",
+		Country: "some-country",
+	}
+}
+
+type dummyEquipCatDecoder struct {
+}
+
+func (dummyEquipCatDecoder) Decode(ID string) (bool, string) {
+	return ID == "U", "some-equip-cat"
+}
+
+type dummyLengthDecoder struct {
+}
+
+func (dummyLengthDecoder) Decode(code string) (bool, string) {
+	return code == "2", "some-length"
+}
+
+type dummyHeightWidthDecoder struct {
+}
+
+func (dummyHeightWidthDecoder) Decode(code string) (bool, string, string) {
+	return code == "2", "some-height", "some-width"
+}
+
+type dummyTypeDecoder struct {
+}
+
+func (dummyTypeDecoder) Decode(code string) (bool, string, string) {
+	return code == "G1", "some-type", "some-group"
+}
+
+func newDummyValidator() *Validator {
+	return NewValidatorFromDecoders(Decoders{
+		Owner:       dummyOwnerDecoder{},
+		EquipCat:    dummyEquipCatDecoder{},
+		Length:      dummyLengthDecoder{},
+		HeightWidth: dummyHeightWidthDecoder{},
+		Type:        dummyTypeDecoder{},
+	})
+}
+
+func TestValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		want      Result
+		wantParts []Part
+	}{
+		{
+			"Validate container number with size and type",
+			"ABC U 123456 0 22G1",
+			Result{
+				Number: Number{
+					OwnerCode:    "ABC",
+					EquipCatID:   "U",
+					SerialNumber: "123456",
+					CheckDigit:   0,
+					SizeType:     SizeType{LengthCode: "2", HeightWidthCode: "2", TypeCode: "G1"},
+				},
+				Owner: Owner{
+					Code:    "ABC",
+					Company: "some-company",
+					City:    "some-city",
+					Country: "some-country",
+				},
+				EquipCat:  "some-equip-cat",
+				Length:    "some-length",
+				Height:    "some-height",
+				Width:     "some-width",
+				TypeInfo:  "some-type",
+				GroupInfo: "some-group",
+			},
+			nil,
+		},
+		{
+			"Validate container number with possible transposition errors",
+			"WSL U 801743 0",
+			Result{
+				Number: Number{OwnerCode: "WSL", EquipCatID: "U", SerialNumber: "801743", CheckDigit: 0},
+				Owner: Owner{
+					Code:    "WSL",
+					Company: "some-company",
+					City:    "some-city",
+					Country: "some-country",
+				},
+				EquipCat: "some-equip-cat",
+				PossibleTranspositionErrors: []Number{
+					{OwnerCode: "WSL", EquipCatID: "U", SerialNumber: "810743", CheckDigit: 0},
+					{OwnerCode: "WSL", EquipCatID: "U", SerialNumber: "807143", CheckDigit: 0},
+					{OwnerCode: "WSL", EquipCatID: "U", SerialNumber: "801740", CheckDigit: 3},
+				},
+			},
+			nil,
+		},
+		{
+			"Validate unregistered owner and unknown size and type",
+			"XYZ U 123456 0 99X1",
+			Result{
+				Number: Number{
+					OwnerCode:    "XYZ",
+					EquipCatID:   "U",
+					SerialNumber: "123456",
+					CheckDigit:   0,
+					SizeType:     SizeType{LengthCode: "9", HeightWidthCode: "9", TypeCode: "X1"},
+				},
+				EquipCat: "some-equip-cat",
+			},
+			[]Part{PartOwner, PartLength, PartHeightWidth, PartType},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newDummyValidator().Validate(tt.in)
+			var gotParts []Part
+			for _, err := range got.Errors {
+				gotParts = append(gotParts, err.(*PartError).Part)
+			}
+			if !reflect.DeepEqual(gotParts, tt.wantParts) {
+				t.Errorf("Validator.Validate() error parts = %v, want %v", gotParts, tt.wantParts)
+			}
+			if got.Valid() != (len(tt.wantParts) == 0) {
+				t.Errorf("Result.Valid() = %v, want %v", got.Valid(), len(tt.wantParts) == 0)
+			}
+			got.Errors = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validator.Validate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	got := Validate("XYZ J 123456 3")
+	if !got.Valid() {
+		t.Errorf("Validate() errors = %v, want none", got.Errors)
+	}
+	if got.Owner != (Owner{}) {
+		t.Errorf("Validate() owner = %v, want no owner", got.Owner)
+	}
+}
+
+func TestNewValidatorFromDecoders(t *testing.T) {
+	got := NewValidatorFromDecoders(Decoders{Owner: dummyOwnerDecoder{}}).Validate("XYZ J 123456 3 99X1")
+	var gotParts []Part
+	for _, err := range got.Errors {
+		gotParts = append(gotParts, err.(*PartError).Part)
+	}
+	if want := []Part{PartOwner}; !reflect.DeepEqual(gotParts, want) {
+		t.Errorf("Validator.Validate() error parts = %v, want %v", gotParts, want)
+	}
+}